@@ -0,0 +1,297 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/sliceutil"
+	"github.com/essentialkaos/ek/v13/spinner"
+	"github.com/essentialkaos/ek/v13/terminal"
+	"github.com/essentialkaos/ek/v13/terminal/input"
+	"github.com/essentialkaos/ek/v13/terminal/tty"
+	"github.com/essentialkaos/ek/v13/timeutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// abuser contains info about flagged IP and user agent pair
+type abuser struct {
+	IP        string
+	UserAgent string
+	Reasons   []string
+	Clients   []*mountClient // Currently connected clients
+	Sessions  map[int]bool   // IDs of young sessions seen during watching
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// minReconnectSessions is min number of young sessions with the same IP and user
+// agent treated as reconnect loop
+const minReconnectSessions = 4
+
+// abusersPollInterval is interval between listeners list requests
+const abusersPollInterval = time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// crawlerAgents is list of user agent substrings used by crawlers and scripts
+var crawlerAgents = []string{
+	"bot", "crawl", "spider", "slurp", "scrapy", "curl/", "wget/",
+	"python-requests", "python-urllib", "go-http-client", "libwww-perl",
+	"headlesschrome",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findAbusers prints info about clients with duplicate connections or suspicious
+// behavior and offers to kill their connections
+func findAbusers() {
+	reconnectTime, err := timeutil.ParseDuration(options.GetS(OPT_RECONNECT_TIME))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_RECONNECT_TIME), err)
+	}
+
+	polls, err := watchClients(reconnectTime)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	abusers := detectAbusers(polls, options.GetI(OPT_MAX_CONNS), reconnectTime)
+
+	if len(abusers) == 0 {
+		fmtc.Println("{g}No abusers found{!}")
+		return
+	}
+
+	printAbusers(abusers)
+
+	if !confirmAbusersKill(abusers) {
+		return
+	}
+
+	if !killAbusers(abusers) {
+		os.Exit(1)
+	}
+}
+
+// watchClients requests listeners of all mountpoints during given time and
+// returns results of all requests
+func watchClients(dur time.Duration) ([][]*mountClient, error) {
+	var polls [][]*mountClient
+
+	spinner.Show("Watching listeners for %s", timeutil.PrettyDuration(dur))
+
+	for i := time.Duration(0); ; i += abusersPollInterval {
+		clients, err := listAllClients()
+
+		if err != nil {
+			spinner.Done(false)
+			return nil, err
+		}
+
+		polls = append(polls, clients)
+
+		if i+abusersPollInterval >= dur {
+			break
+		}
+
+		time.Sleep(abusersPollInterval)
+	}
+
+	spinner.Done(true)
+
+	return polls, nil
+}
+
+// detectAbusers groups clients by IP and user agent and returns groups which
+// look suspicious. Clients from the last poll are treated as currently connected,
+// clients from all polls are used for reconnect loops detection.
+func detectAbusers(polls [][]*mountClient, maxConns int, reconnectTime time.Duration) []*abuser {
+	index := map[string]*abuser{}
+
+	for i, clients := range polls {
+		for _, c := range clients {
+			key := c.IP + " " + c.UserAgent
+
+			if index[key] == nil {
+				index[key] = &abuser{IP: c.IP, UserAgent: c.UserAgent, Sessions: map[int]bool{}}
+			}
+
+			if c.Connected < reconnectTime {
+				index[key].Sessions[c.ID] = true
+			}
+
+			if i == len(polls)-1 {
+				index[key].Clients = append(index[key].Clients, c)
+			}
+		}
+	}
+
+	var result []*abuser
+
+	for _, a := range index {
+		if len(a.Clients) > maxConns {
+			a.Reasons = append(a.Reasons, "connections")
+		}
+
+		if len(a.Sessions) >= minReconnectSessions {
+			a.Reasons = append(a.Reasons, "reconnects")
+		}
+
+		if isCrawlerAgent(a.UserAgent) && len(a.Clients) != 0 {
+			a.Reasons = append(a.Reasons, "crawler")
+		}
+
+		if len(a.Reasons) != 0 {
+			result = append(result, a)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Clients) == len(result[j].Clients) {
+			if result[i].IP == result[j].IP {
+				return result[i].UserAgent < result[j].UserAgent
+			}
+
+			return result[i].IP < result[j].IP
+		}
+
+		return len(result[i].Clients) > len(result[j].Clients)
+	})
+
+	return result
+}
+
+// printAbusers prints table with flagged clients
+func printAbusers(abusers []*abuser) {
+	t := table.NewTable("ip", "conns", "mounts", "reason", "user-agent")
+	t.SetAlignments(table.ALIGN_RIGHT, table.ALIGN_RIGHT, table.ALIGN_LEFT, table.ALIGN_LEFT)
+	t.SetSizes(14, 6, 20, 24)
+
+	fmtc.NewLine()
+
+	for _, a := range abusers {
+		var mounts []string
+
+		for _, c := range a.Clients {
+			if !sliceutil.Contains(mounts, c.Mount) {
+				mounts = append(mounts, c.Mount)
+			}
+		}
+
+		t.Print(
+			a.IP, fmtutil.PrettyNum(len(a.Clients)),
+			strings.Join(mounts, " "), strings.Join(a.Reasons, " "),
+			a.UserAgent,
+		)
+	}
+
+	t.Separator()
+
+	fmtc.NewLine()
+}
+
+// confirmAbusersKill asks user for confirmation of connections killing
+func confirmAbusersKill(abusers []*abuser) bool {
+	var total int
+
+	for _, a := range abusers {
+		total += len(a.Clients)
+	}
+
+	switch {
+	case total == 0:
+		return false
+	case options.GetB(OPT_YES):
+		return true
+	case !tty.IsTTY():
+		return false
+	}
+
+	ok, err := input.ReadAnswer(
+		fmtc.Sprintf("Kill %s flagged connections?", fmtutil.PrettyNum(total)), "N",
+	)
+
+	if err != nil {
+		return false
+	}
+
+	fmtc.NewLine()
+
+	return ok
+}
+
+// killAbusers kills flagged connections. Other clients with the same IP (e.g.
+// behind NAT) are not affected.
+func killAbusers(abusers []*abuser) bool {
+	hasErrors := false
+
+	for _, a := range abusers {
+		for _, c := range a.Clients {
+			err := client.KillClient(c.Mount, c.ID)
+
+			if err != nil {
+				terminal.Error("Can't kill client %d (%s) on %s: %v", c.ID, c.IP, c.Mount, err)
+				hasErrors = true
+			} else {
+				fmtc.Printfn("{g}Client %d (%s) successfully detached from %s{!}", c.ID, c.IP, c.Mount)
+			}
+		}
+	}
+
+	return !hasErrors
+}
+
+// isCrawlerAgent returns true if given user agent belongs to crawler or script
+func isCrawlerAgent(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+
+	for _, a := range crawlerAgents {
+		if strings.Contains(userAgent, a) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdAbusers shows help for "abusers" command
+func helpCmdAbusers() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Watches listeners of all mountpoints for reconnect time, groups them by IP")
+	fmtc.Println("  and user agent and shows groups with too many concurrent connections, short")
+	fmtc.Println("  reconnect loops {s-}(many new sessions during reconnect time){!} or known crawler")
+	fmtc.Println("  user agents. After that, it offers to kill connections of flagged groups.")
+	fmtc.Println("  Other listeners with the same IP are not affected.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!}", APP, CMD_ABUSERS)
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-16s{!} - Max number of connections from one IP and user agent {s-}(default: 5){!}", options.F(OPT_MAX_CONNS))
+	fmtc.Printfn("  {g}%-16s{!} - Watching time for reconnect loop detection {s-}(default: 15s){!}", options.F(OPT_RECONNECT_TIME))
+	fmtc.Printfn("  {g}%-16s{!} - Kill flagged connections without confirmation", options.F(OPT_YES))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s", APP, CMD_ABUSERS)
+	fmtc.Printfn("  %s %s %s 10 %s 1m", APP, CMD_ABUSERS, options.F(OPT_MAX_CONNS), options.F(OPT_RECONNECT_TIME))
+	fmtc.NewLine()
+}
@@ -0,0 +1,105 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_MP3 = "mp3"
+	FORMAT_AAC = "aac"
+	FORMAT_OGG = "ogg"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Chunk is part of audio stream (frame or page) with known duration
+type Chunk struct {
+	Data       []byte
+	Codec      string
+	Duration   time.Duration
+	SampleRate int
+	Channels   int
+	Bitrate    int // Bitrate in kbit/s (0 if unknown)
+}
+
+// Reader reads audio stream chunk by chunk
+type Reader interface {
+	// Read reads next chunk of audio stream
+	Read() (*Chunk, error)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// contentTypes is map format → content type
+var contentTypes = map[string]string{
+	FORMAT_MP3: "audio/mpeg",
+	FORMAT_AAC: "audio/aac",
+	FORMAT_OGG: "audio/ogg",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewReader creates new audio stream reader for given format
+func NewReader(r io.Reader, format string) (Reader, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+
+	switch format {
+	case FORMAT_MP3, FORMAT_AAC:
+		return &frameReader{r: br}, nil
+	case FORMAT_OGG:
+		return &oggReader{r: br, streams: map[uint32]*oggStreamInfo{}}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported audio format %q", format)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FormatByExt returns audio format for given file name
+func FormatByExt(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".mp3", ".mp2":
+		return FORMAT_MP3
+	case ".aac", ".adts":
+		return FORMAT_AAC
+	case ".ogg", ".oga", ".opus":
+		return FORMAT_OGG
+	}
+
+	return ""
+}
+
+// FormatByContentType returns audio format for given content type
+func FormatByContentType(contentType string) string {
+	contentType, _, _ = strings.Cut(strings.ToLower(contentType), ";")
+
+	switch strings.TrimSpace(contentType) {
+	case "audio/mpeg", "audio/mp3", "audio/mpeg3":
+		return FORMAT_MP3
+	case "audio/aac", "audio/aacp", "audio/x-aac":
+		return FORMAT_AAC
+	case "application/ogg", "audio/ogg", "audio/opus", "audio/vorbis", "video/ogg":
+		return FORMAT_OGG
+	}
+
+	return ""
+}
+
+// ContentType returns content type for given format
+func ContentType(format string) string {
+	return contentTypes[format]
+}
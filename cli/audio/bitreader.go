@@ -0,0 +1,80 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// bitReader reads data bit by bit (MSB first). Reading beyond the end of data
+// returns zeros and sets overflow flag.
+type bitReader struct {
+	data     []byte
+	pos      uint
+	overflow bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads unsigned value with given number of bits (up to 64)
+func (r *bitReader) Read(bits uint) uint64 {
+	var value uint64
+
+	for bits > 0 {
+		index := r.pos >> 3
+
+		if index >= uint(len(r.data)) {
+			r.overflow = true
+			return value << bits
+		}
+
+		offset := r.pos & 7
+		n := min(8-offset, bits)
+		b := uint64(r.data[index]>>(8-offset-n)) & (1<<n - 1)
+
+		value = value<<n | b
+		r.pos += n
+		bits -= n
+	}
+
+	return value
+}
+
+// ReadSigned reads signed value in two's complement with given number of bits
+func (r *bitReader) ReadSigned(bits uint) int64 {
+	if bits == 0 {
+		return 0
+	}
+
+	return int64(r.Read(bits)<<(64-bits)) >> (64 - bits)
+}
+
+// ReadBool reads one bit as a boolean value
+func (r *bitReader) ReadBool() bool {
+	return r.Read(1) == 1
+}
+
+// ReadUnary reads value in unary coding (zeros terminated by one)
+func (r *bitReader) ReadUnary() uint32 {
+	var value uint32
+
+	for !r.ReadBool() {
+		if r.overflow {
+			return value
+		}
+
+		value++
+	}
+
+	return value
+}
+
+// Skip skips given number of bits
+func (r *bitReader) Skip(bits uint) {
+	r.pos += bits
+
+	if r.pos > uint(len(r.data))*8 {
+		r.overflow = true
+	}
+}
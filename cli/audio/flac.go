@@ -0,0 +1,356 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/binary"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FLAC_BLOCK_SIZE  = 4096
+	FLAC_SAMPLE_RATE = 44100
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FLACEncoder is minimal FLAC encoder for 16-bit mono audio with fixed block
+// size. It uses constant, fixed or verbatim subframes.
+type FLACEncoder struct {
+	frame uint64
+}
+
+// bitWriter writes data bit by bit (MSB first)
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// maxRiceParam is max Rice parameter for 4-bit parameter coding (15 is escape code)
+const maxRiceParam = 14
+
+// maxFixedOrder is max order of fixed predictor
+const maxFixedOrder = 4
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OggFLACHeaders returns Ogg FLAC header packets (mapping header with STREAMINFO
+// and VORBIS_COMMENT metadata block)
+func OggFLACHeaders(vendor string, comments []string) [][]byte {
+	head := []byte{0x7F, 'F', 'L', 'A', 'C', 1, 0, 0, 1, 'f', 'L', 'a', 'C'}
+	head = append(head, flacStreamInfo()...)
+
+	return [][]byte{head, flacVorbisComment(vendor, comments)}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Reset resets frame counter (used for new logical stream)
+func (e *FLACEncoder) Reset() {
+	e.frame = 0
+}
+
+// Encode encodes FLAC_BLOCK_SIZE samples to FLAC frame
+func (e *FLACEncoder) Encode(samples []int16) []byte {
+	w := &bitWriter{}
+
+	w.Write(0xFFF8, 16) // Sync code, fixed block size
+	w.Write(0xC, 4)     // 4096 samples per block
+	w.Write(0x9, 4)     // 44.1 kHz
+	w.Write(0x0, 4)     // Mono
+	w.Write(0x4, 3)     // 16 bits per sample
+	w.Write(0x0, 1)     // Reserved
+	w.WriteBytes(encodeFrameNumber(e.frame))
+	w.Write(uint64(crc8(w.Bytes())), 8)
+
+	encodeSubframe(w, samples)
+
+	w.Align()
+	w.Write(uint64(crc16(w.Bytes())), 16)
+
+	e.frame++
+
+	return w.Bytes()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes given number of low bits of value
+func (w *bitWriter) Write(value uint64, bits uint) {
+	for bits > 32 {
+		w.Write(value>>32, bits-32)
+		bits = 32
+	}
+
+	w.acc = w.acc<<bits | value&(1<<bits-1)
+	w.nbits += bits
+
+	for w.nbits >= 8 {
+		w.nbits -= 8
+		w.buf = append(w.buf, byte(w.acc>>w.nbits))
+	}
+}
+
+// WriteBytes writes byte slice
+func (w *bitWriter) WriteBytes(data []byte) {
+	for _, b := range data {
+		w.Write(uint64(b), 8)
+	}
+}
+
+// WriteUnary writes value in unary coding (zeros terminated by one)
+func (w *bitWriter) WriteUnary(value uint32) {
+	for value >= 32 {
+		w.Write(0, 32)
+		value -= 32
+	}
+
+	w.Write(1, uint(value)+1)
+}
+
+// Align pads data with zeros to byte boundary
+func (w *bitWriter) Align() {
+	if w.nbits > 0 {
+		w.Write(0, 8-w.nbits)
+	}
+}
+
+// Bytes returns written bytes (without unaligned bits)
+func (w *bitWriter) Bytes() []byte {
+	return w.buf
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeSubframe encodes samples using the smallest subframe type
+func encodeSubframe(w *bitWriter, samples []int16) {
+	if isConstant(samples) {
+		w.Write(0x00, 8) // Constant subframe
+		w.Write(uint64(uint16(samples[0])), 16)
+		return
+	}
+
+	var order, param uint
+	var residual []uint32
+
+	// Size of verbatim subframe is used as a baseline
+	size := uint64(len(samples)) * 16
+
+	for o := uint(0); o <= maxFixedOrder; o++ {
+		r := fixedResidual(samples, o)
+		p, bits := findRiceParam(r)
+		bits += uint64(o) * 16 // Warm-up samples
+
+		if bits < size {
+			order, param, residual, size = o, p, r, bits
+		}
+	}
+
+	if residual == nil {
+		w.Write(0x02, 8) // Verbatim subframe
+
+		for _, s := range samples {
+			w.Write(uint64(uint16(s)), 16)
+		}
+
+		return
+	}
+
+	w.Write(uint64(0x10|order<<1), 8) // Fixed subframe
+
+	for _, s := range samples[:order] {
+		w.Write(uint64(uint16(s)), 16)
+	}
+
+	w.Write(0, 2) // Rice coding with 4-bit parameter
+	w.Write(0, 4) // Partition order
+	w.Write(uint64(param), 4)
+
+	for _, u := range residual {
+		w.WriteUnary(u >> param)
+		w.Write(uint64(u), param)
+	}
+}
+
+// fixedResidual calculates zigzag-encoded residual of fixed predictor with
+// given order
+func fixedResidual(samples []int16, order uint) []uint32 {
+	residual := make([]uint32, len(samples)-int(order))
+
+	for i := int(order); i < len(samples); i++ {
+		var r int32
+
+		x := func(n int) int32 { return int32(samples[i-n]) }
+
+		switch order {
+		case 0:
+			r = x(0)
+		case 1:
+			r = x(0) - x(1)
+		case 2:
+			r = x(0) - 2*x(1) + x(2)
+		case 3:
+			r = x(0) - 3*x(1) + 3*x(2) - x(3)
+		case 4:
+			r = x(0) - 4*x(1) + 6*x(2) - 4*x(3) + x(4)
+		}
+
+		residual[i-int(order)] = uint32(r<<1) ^ uint32(r>>31) // Zigzag
+	}
+
+	return residual
+}
+
+// findRiceParam finds optimal Rice parameter for residual. Returns parameter
+// and size of encoded residual in bits.
+func findRiceParam(residual []uint32) (uint, uint64) {
+	var bestParam uint
+	var bestSize uint64
+
+	for param := uint(0); param <= maxRiceParam; param++ {
+		size := uint64(len(residual)) * uint64(param+1)
+
+		for _, u := range residual {
+			size += uint64(u >> param)
+		}
+
+		if param == 0 || size < bestSize {
+			bestParam, bestSize = param, size
+		}
+	}
+
+	return bestParam, bestSize
+}
+
+// isConstant returns true if all samples are equal
+func isConstant(samples []int16) bool {
+	for _, s := range samples[1:] {
+		if s != samples[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encodeFrameNumber encodes frame number using UTF-8 like coding
+func encodeFrameNumber(n uint64) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+
+	var size int
+
+	switch {
+	case n < 0x800:
+		size = 2
+	case n < 0x10000:
+		size = 3
+	case n < 0x200000:
+		size = 4
+	case n < 0x4000000:
+		size = 5
+	case n < 0x80000000:
+		size = 6
+	default:
+		size = 7
+	}
+
+	result := make([]byte, size)
+
+	for i := size - 1; i > 0; i-- {
+		result[i] = 0x80 | byte(n&0x3F)
+		n >>= 6
+	}
+
+	result[0] = byte(uint16(0xFF00)>>size) | byte(n)
+
+	return result
+}
+
+// flacStreamInfo returns STREAMINFO metadata block
+func flacStreamInfo() []byte {
+	w := &bitWriter{}
+
+	w.Write(0, 1)                  // Not last metadata block
+	w.Write(0, 7)                  // STREAMINFO
+	w.Write(34, 24)                // Block size
+	w.Write(FLAC_BLOCK_SIZE, 16)   // Min block size
+	w.Write(FLAC_BLOCK_SIZE, 16)   // Max block size
+	w.Write(0, 24)                 // Min frame size (unknown)
+	w.Write(0, 24)                 // Max frame size (unknown)
+	w.Write(FLAC_SAMPLE_RATE, 20)  // Sample rate
+	w.Write(0, 3)                  // Channels - 1
+	w.Write(15, 5)                 // Bits per sample - 1
+	w.Write(0, 36)                 // Total samples (unknown)
+	w.WriteBytes(make([]byte, 16)) // MD5 (unknown)
+
+	return w.Bytes()
+}
+
+// flacVorbisComment returns VORBIS_COMMENT metadata block
+func flacVorbisComment(vendor string, comments []string) []byte {
+	var data []byte
+
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(vendor)))
+	data = append(data, vendor...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(comments)))
+
+	for _, c := range comments {
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(c)))
+		data = append(data, c...)
+	}
+
+	header := []byte{0x84, byte(len(data) >> 16), byte(len(data) >> 8), byte(len(data))}
+
+	return append(header, data...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// crc8 calculates CRC-8 of FLAC frame header (polynomial 0x07)
+func crc8(data []byte) byte {
+	var crc byte
+
+	for _, b := range data {
+		crc ^= b
+
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// crc16 calculates CRC-16 of FLAC frame (polynomial 0x8005)
+func crc16(data []byte) uint16 {
+	var crc uint16
+
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
@@ -0,0 +1,338 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// flacFrame is decoded FLAC frame
+type flacFrame struct {
+	Samples [][]int32 // Samples of every channel
+	BPS     int       // Bits per sample
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	flacChannelLeftSide  = 8
+	flacChannelSideRight = 9
+	flacChannelMidSide   = 10
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// flacSampleSizes contains sample sizes for sample size codes (0 - from STREAMINFO)
+var flacSampleSizes = [8]int{0, 8, 12, 0, 16, 20, 24, 32}
+
+// errInvalidFLACFrame is returned if FLAC frame can't be decoded
+var errInvalidFLACFrame = errors.New("Invalid FLAC frame")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decodeFLACFrame decodes FLAC frame. Bits per sample from STREAMINFO is used
+// if frame header doesn't contain sample size.
+func decodeFLACFrame(data []byte, streamBPS int) (*flacFrame, error) {
+	r := &bitReader{data: data}
+
+	if r.Read(14) != 0x3FFE {
+		return nil, errInvalidFLACFrame
+	}
+
+	r.Skip(2) // Reserved bit and blocking strategy
+
+	blockSizeCode := r.Read(4)
+	sampleRateCode := r.Read(4)
+	channelsCode := int(r.Read(4))
+	bps := flacSampleSizes[r.Read(3)]
+
+	r.Skip(1) // Reserved bit
+
+	// Frame or sample number in UTF-8-like coding
+	if first := r.Read(8); first&0x80 != 0 {
+		for mask := uint64(0x40); first&mask != 0; mask >>= 1 {
+			r.Skip(8)
+		}
+	}
+
+	var blockSize int
+
+	switch {
+	case blockSizeCode == 1:
+		blockSize = 192
+	case blockSizeCode <= 5 && blockSizeCode >= 2:
+		blockSize = 576 << (blockSizeCode - 2)
+	case blockSizeCode == 6:
+		blockSize = int(r.Read(8)) + 1
+	case blockSizeCode == 7:
+		blockSize = int(r.Read(16)) + 1
+	case blockSizeCode >= 8:
+		blockSize = 256 << (blockSizeCode - 8)
+	default:
+		return nil, errInvalidFLACFrame
+	}
+
+	switch sampleRateCode {
+	case 12:
+		r.Skip(8)
+	case 13, 14:
+		r.Skip(16)
+	case 15:
+		return nil, errInvalidFLACFrame
+	}
+
+	r.Skip(8) // CRC-8
+
+	if bps == 0 {
+		bps = streamBPS
+	}
+
+	channels := channelsCode + 1
+
+	if channelsCode >= flacChannelLeftSide {
+		channels = 2
+	}
+
+	if bps == 0 || channelsCode > flacChannelMidSide {
+		return nil, errInvalidFLACFrame
+	}
+
+	frame := &flacFrame{Samples: make([][]int32, channels), BPS: bps}
+
+	for ch := range frame.Samples {
+		subframeBPS := bps
+
+		// Side channel has one extra bit
+		switch {
+		case ch == 1 && (channelsCode == flacChannelLeftSide || channelsCode == flacChannelMidSide),
+			ch == 0 && channelsCode == flacChannelSideRight:
+			subframeBPS++
+		}
+
+		samples, err := decodeFLACSubframe(r, blockSize, subframeBPS)
+
+		if err != nil {
+			return nil, err
+		}
+
+		frame.Samples[ch] = samples
+	}
+
+	if r.overflow {
+		return nil, errInvalidFLACFrame
+	}
+
+	decorrelateFLAC(frame.Samples, channelsCode)
+
+	return frame, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decodeFLACSubframe decodes subframe with samples of one channel
+func decodeFLACSubframe(r *bitReader, blockSize, bps int) ([]int32, error) {
+	if r.ReadBool() {
+		return nil, errInvalidFLACFrame
+	}
+
+	kind := r.Read(6)
+	wasted := 0
+
+	if r.ReadBool() {
+		wasted = int(r.ReadUnary()) + 1
+		bps -= wasted
+	}
+
+	if bps <= 0 {
+		return nil, errInvalidFLACFrame
+	}
+
+	var err error
+
+	samples := make([]int32, blockSize)
+
+	switch {
+	case kind == 0:
+		value := int32(r.ReadSigned(uint(bps)))
+
+		for i := range samples {
+			samples[i] = value
+		}
+
+	case kind == 1:
+		for i := range samples {
+			samples[i] = int32(r.ReadSigned(uint(bps)))
+		}
+
+	case kind >= 8 && kind <= 12:
+		err = decodeFLACFixed(r, samples, int(kind-8), bps)
+
+	case kind >= 32:
+		err = decodeFLACLPC(r, samples, int(kind-31), bps)
+
+	default:
+		err = errInvalidFLACFrame
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if wasted > 0 {
+		for i := range samples {
+			samples[i] <<= wasted
+		}
+	}
+
+	return samples, nil
+}
+
+// decodeFLACFixed decodes subframe with fixed predictor
+func decodeFLACFixed(r *bitReader, samples []int32, order, bps int) error {
+	if order > len(samples) {
+		return errInvalidFLACFrame
+	}
+
+	for i := range order {
+		samples[i] = int32(r.ReadSigned(uint(bps)))
+	}
+
+	err := decodeFLACResidual(r, samples, order)
+
+	if err != nil {
+		return err
+	}
+
+	for i := order; i < len(samples); i++ {
+		switch order {
+		case 1:
+			samples[i] += samples[i-1]
+		case 2:
+			samples[i] += 2*samples[i-1] - samples[i-2]
+		case 3:
+			samples[i] += 3*samples[i-1] - 3*samples[i-2] + samples[i-3]
+		case 4:
+			samples[i] += 4*samples[i-1] - 6*samples[i-2] + 4*samples[i-3] - samples[i-4]
+		}
+	}
+
+	return nil
+}
+
+// decodeFLACLPC decodes subframe with linear predictor
+func decodeFLACLPC(r *bitReader, samples []int32, order, bps int) error {
+	if order > len(samples) {
+		return errInvalidFLACFrame
+	}
+
+	for i := range order {
+		samples[i] = int32(r.ReadSigned(uint(bps)))
+	}
+
+	precision := uint(r.Read(4)) + 1
+	shift := r.ReadSigned(5)
+
+	if precision > 15 || shift < 0 {
+		return errInvalidFLACFrame
+	}
+
+	coefs := make([]int64, order)
+
+	for i := range coefs {
+		coefs[i] = r.ReadSigned(precision)
+	}
+
+	err := decodeFLACResidual(r, samples, order)
+
+	if err != nil {
+		return err
+	}
+
+	for i := order; i < len(samples); i++ {
+		var sum int64
+
+		for j, c := range coefs {
+			sum += c * int64(samples[i-1-j])
+		}
+
+		samples[i] += int32(sum >> shift)
+	}
+
+	return nil
+}
+
+// decodeFLACResidual decodes Rice-coded residual to samples after warm-up
+// samples
+func decodeFLACResidual(r *bitReader, samples []int32, order int) error {
+	method := r.Read(2)
+
+	if method > 1 {
+		return errInvalidFLACFrame
+	}
+
+	paramBits := uint(4 + method)
+	escape := uint64(1)<<paramBits - 1
+	partitionOrder := r.Read(4)
+	partitionSize := len(samples) >> partitionOrder
+
+	if partitionSize < order || partitionSize<<partitionOrder != len(samples) {
+		return errInvalidFLACFrame
+	}
+
+	i := order
+
+	for p := 0; p < 1<<partitionOrder; p++ {
+		end := (p + 1) * partitionSize
+		param := r.Read(paramBits)
+
+		if param == escape {
+			bits := uint(r.Read(5))
+
+			for ; i < end; i++ {
+				samples[i] = int32(r.ReadSigned(bits))
+			}
+
+			continue
+		}
+
+		for ; i < end; i++ {
+			value := uint64(r.ReadUnary())<<param | r.Read(uint(param))
+			samples[i] = int32(value>>1) ^ -int32(value&1)
+		}
+
+		if r.overflow {
+			return errInvalidFLACFrame
+		}
+	}
+
+	return nil
+}
+
+// decorrelateFLAC restores left and right channels from side channel
+func decorrelateFLAC(samples [][]int32, channelsCode int) {
+	switch channelsCode {
+	case flacChannelLeftSide:
+		for i, side := range samples[1] {
+			samples[1][i] = samples[0][i] - side
+		}
+
+	case flacChannelSideRight:
+		for i, side := range samples[0] {
+			samples[0][i] = side + samples[1][i]
+		}
+
+	case flacChannelMidSide:
+		for i, side := range samples[1] {
+			mid := samples[0][i]<<1 | side&1
+			samples[0][i] = (mid + side) >> 1
+			samples[1][i] = (mid - side) >> 1
+		}
+	}
+}
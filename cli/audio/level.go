@@ -0,0 +1,476 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/go-mp3"
+	"github.com/jfreymuth/vorbis"
+	"github.com/pion/opus"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MIN_LEVEL is level of digital silence in dBFS
+const MIN_LEVEL = -120.0
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LevelReader decodes audio stream and measures its signal level
+type LevelReader struct {
+	r      Reader
+	format string
+
+	mp3    *mp3.Decoder
+	mp3src *chunkSource
+	pcm    []byte
+
+	decoded      bool // At least one frame was decoded
+	decodeErrors int  // Number of frames in a row which can't be decoded
+
+	ogg         map[uint32]*oggDecoder
+	unsupported string // Codec of logical Ogg stream which can't be decoded
+}
+
+// chunkSource is io.Reader with data of audio chunks with given codec
+type chunkSource struct {
+	r     *LevelReader
+	codec string
+	data  []byte
+	err   error
+}
+
+// oggDecoder decodes packets of logical Ogg stream
+type oggDecoder struct {
+	codec   string
+	packet  []byte
+	partial bool // Packet continues on the next page
+
+	flacBPS int // Bits per sample from STREAMINFO
+
+	vorbis *vorbis.Decoder
+
+	opus         *opus.Decoder
+	opusChannels int
+	opusBuf      []float32
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// maxDecodeErrors is max number of MP3 frames in a row which can't be decoded
+const maxDecodeErrors = 50
+
+// maxSkippedChunks is max number of chunks in a row with unexpected codec
+const maxSkippedChunks = 50
+
+// maxOpusFrameSamples is max number of samples per channel in Opus packet
+// (120 ms at 48 kHz)
+const maxOpusFrameSamples = 5760
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrUnsupportedCodec is returned if stream codec can't be decoded
+var ErrUnsupportedCodec = errors.New("Unsupported codec")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewLevelReader creates new level reader for stream in given format
+func NewLevelReader(r io.Reader, format string) (*LevelReader, error) {
+	switch format {
+	case "":
+		return nil, fmt.Errorf("%w: unknown audio format", ErrUnsupportedCodec)
+	case FORMAT_AAC:
+		return nil, fmt.Errorf("%w: aac", ErrUnsupportedCodec)
+	}
+
+	cr, err := NewReader(r, format)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &LevelReader{r: cr, format: format, ogg: map[uint32]*oggDecoder{}}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read decodes audio with given duration and returns its RMS level in dBFS
+func (r *LevelReader) Read(window time.Duration) (float64, error) {
+	var sum float64
+	var count int
+	var duration time.Duration
+
+	for duration < window || count == 0 {
+		samples, dur, err := r.decode()
+
+		if err != nil {
+			return 0, err
+		}
+
+		if len(samples) == 0 {
+			continue
+		}
+
+		for _, v := range samples {
+			sum += float64(v) * float64(v)
+		}
+
+		count += len(samples)
+		duration += dur
+	}
+
+	return amplitudeToLevel(math.Sqrt(sum / float64(count))), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decode decodes next part of the stream and returns normalized samples and
+// their duration
+func (r *LevelReader) decode() (samples []float32, duration time.Duration, err error) {
+	// Decoders may panic on broken data
+	defer func() {
+		if rec := recover(); rec != nil {
+			samples, duration, err = nil, 0, fmt.Errorf("Can't decode audio: %v", rec)
+		}
+	}()
+
+	if r.format == FORMAT_MP3 {
+		return r.decodeMP3()
+	}
+
+	return r.decodeOgg()
+}
+
+// decodeMP3 decodes next MPEG audio Layer III frame. Decoder is recreated if
+// frame can't be decoded.
+func (r *LevelReader) decodeMP3() ([]float32, time.Duration, error) {
+	if r.mp3 == nil {
+		r.mp3src = &chunkSource{r: r, codec: "mp3"}
+		dec, err := mp3.NewDecoder(r.mp3src)
+
+		switch {
+		case r.mp3src.err != nil:
+			return nil, 0, r.mp3src.err
+		case err != nil:
+			return nil, 0, r.decodeError("mp3", err)
+		}
+
+		r.mp3 = dec
+		r.pcm = make([]byte, 4608) // 1152 stereo 16-bit samples
+	}
+
+	n, err := r.mp3.Read(r.pcm)
+
+	if err != nil {
+		if r.mp3src.err != nil {
+			return nil, 0, r.mp3src.err
+		}
+
+		r.mp3 = nil
+
+		return nil, 0, r.decodeError("mp3", err)
+	}
+
+	r.decoded, r.decodeErrors = true, 0
+
+	samples := make([]float32, n/2)
+
+	for i := range samples {
+		samples[i] = float32(int16(binary.LittleEndian.Uint16(r.pcm[i*2:]))) / 32768
+	}
+
+	return samples, time.Duration(n/4) * time.Second / time.Duration(r.mp3.SampleRate()), nil
+}
+
+// decodeOgg decodes packets from the next Ogg page. Pages of logical streams
+// which can't be decoded (e.g. video or metadata) are ignored.
+func (r *LevelReader) decodeOgg() ([]float32, time.Duration, error) {
+	chunk, err := r.r.Read()
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	page := chunk.Data
+
+	if len(page) < oggHeaderSize || len(page) < oggHeaderSize+int(page[26]) || !isOggPageValid(page) {
+		return nil, 0, nil
+	}
+
+	flags := page[5]
+	serial := binary.LittleEndian.Uint32(page[14:18])
+	lacing := page[oggHeaderSize : oggHeaderSize+int(page[26])]
+	data := page[oggHeaderSize+len(lacing):]
+
+	d := r.ogg[serial]
+
+	if d == nil || flags&stream.OGG_FLAG_BOS != 0 {
+		switch {
+		case chunk.Codec == "flac", chunk.Codec == "vorbis", chunk.Codec == "opus":
+			d = &oggDecoder{codec: chunk.Codec}
+			r.ogg[serial], r.unsupported = d, ""
+		case flags&stream.OGG_FLAG_BOS != 0:
+			if len(r.ogg) == 0 {
+				r.unsupported = chunk.Codec
+			}
+
+			return nil, 0, nil
+		case len(r.ogg) == 0 && r.unsupported != "":
+			// All streams were started, but none of them can be decoded
+			return nil, 0, fmt.Errorf("%w: %s", ErrUnsupportedCodec, r.unsupported)
+		default:
+			// Headers of the stream were lost
+			return nil, 0, nil
+		}
+	}
+
+	if flags&stream.OGG_FLAG_EOS != 0 {
+		delete(r.ogg, serial)
+	}
+
+	switch {
+	case flags&stream.OGG_FLAG_CONTINUED == 0:
+		// Previous packet wasn't finished
+		d.packet = nil
+	case !d.partial:
+		// Beginning of the packet was lost
+		lacing, data = skipOggPacket(lacing, data)
+	}
+
+	var samples []float32
+
+	for _, l := range lacing {
+		if int(l) > len(data) {
+			return nil, 0, nil
+		}
+
+		d.packet = append(d.packet, data[:l]...)
+		data = data[l:]
+
+		if l < 255 {
+			samples, err = d.decodePacket(samples)
+			d.packet = nil
+
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	d.partial = len(lacing) > 0 && lacing[len(lacing)-1] == 255
+
+	return samples, chunk.Duration, nil
+}
+
+// decodeError counts frames which can't be decoded and returns error if there
+// are too many of them in a row
+func (r *LevelReader) decodeError(codec string, err error) error {
+	r.decodeErrors++
+
+	switch {
+	case r.decodeErrors < maxDecodeErrors:
+		return nil
+	case !r.decoded:
+		return fmt.Errorf("%w: %s (%v)", ErrUnsupportedCodec, codec, err)
+	}
+
+	return fmt.Errorf("Can't decode %s frame: %w", strings.ToUpper(codec), err)
+}
+
+// readChunk reads next chunk with given codec. Chunks with other codecs (e.g.
+// found by false frame sync) are skipped.
+func (r *LevelReader) readChunk(codec string) (*Chunk, error) {
+	for skipped := 0; ; skipped++ {
+		chunk, err := r.r.Read()
+
+		switch {
+		case err != nil:
+			return nil, err
+		case chunk.Codec == codec:
+			return chunk, nil
+		case skipped >= maxSkippedChunks:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedCodec, chunk.Codec)
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decodePacket decodes assembled packet and appends normalized samples to the
+// slice
+func (d *oggDecoder) decodePacket(samples []float32) ([]float32, error) {
+	switch d.codec {
+	case "flac":
+		return d.decodeFLAC(samples), nil
+	case "vorbis":
+		return d.decodeVorbis(samples)
+	}
+
+	return d.decodeOpus(samples)
+}
+
+// decodeFLAC reads bits per sample from STREAMINFO or decodes FLAC frame
+func (d *oggDecoder) decodeFLAC(samples []float32) []float32 {
+	p := d.packet
+
+	switch {
+	case bytes.HasPrefix(p, []byte("\x7fFLAC")) && len(p) >= 31:
+		d.flacBPS = (int(p[29]&0x01)<<4 | int(p[30]>>4)) + 1
+
+	case len(p) >= 2 && p[0] == 0xFF && p[1]&0xFE == 0xF8:
+		frame, err := decodeFLACFrame(p, d.flacBPS)
+
+		if err != nil {
+			return samples
+		}
+
+		scale := math.Ldexp(1, frame.BPS-1)
+
+		for _, ch := range frame.Samples {
+			for _, v := range ch {
+				samples = append(samples, float32(float64(v)/scale))
+			}
+		}
+	}
+
+	return samples
+}
+
+// decodeVorbis reads Vorbis headers or decodes audio packet
+func (d *oggDecoder) decodeVorbis(samples []float32) ([]float32, error) {
+	if d.vorbis == nil {
+		d.vorbis = &vorbis.Decoder{}
+	}
+
+	if vorbis.IsHeader(d.packet) {
+		err := d.vorbis.ReadHeader(d.packet)
+
+		if err != nil {
+			return samples, fmt.Errorf("Can't read Vorbis header: %w", err)
+		}
+
+		return samples, nil
+	}
+
+	// Headers of the stream were lost
+	if !d.vorbis.HeadersRead() {
+		return samples, nil
+	}
+
+	pcm, err := d.vorbis.Decode(d.packet)
+
+	if err != nil {
+		return samples, fmt.Errorf("Can't decode Vorbis packet: %w", err)
+	}
+
+	return append(samples, pcm...), nil
+}
+
+// decodeOpus reads Opus identification header or decodes audio packet
+func (d *oggDecoder) decodeOpus(samples []float32) ([]float32, error) {
+	p := d.packet
+
+	switch {
+	case bytes.HasPrefix(p, []byte("OpusHead")):
+		if len(p) < 19 || p[18] != 0 {
+			return samples, fmt.Errorf("%w: opus (multichannel streams are not supported)", ErrUnsupportedCodec)
+		}
+
+		dec, err := opus.NewDecoderWithOutput(48000, int(p[9]))
+
+		if err != nil {
+			return samples, fmt.Errorf("%w: opus (%v)", ErrUnsupportedCodec, err)
+		}
+
+		d.opus, d.opusChannels = &dec, int(p[9])
+		d.opusBuf = make([]float32, maxOpusFrameSamples*d.opusChannels)
+
+		return samples, nil
+
+	case bytes.HasPrefix(p, []byte("OpusTags")), d.opus == nil:
+		return samples, nil
+	}
+
+	n, err := d.opus.DecodeToFloat32(p, d.opusBuf)
+
+	if err != nil {
+		return samples, fmt.Errorf("Can't decode Opus packet: %w", err)
+	}
+
+	return append(samples, d.opusBuf[:n*d.opusChannels]...), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads data of the next chunk
+func (s *chunkSource) Read(p []byte) (int, error) {
+	for len(s.data) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		chunk, err := s.r.readChunk(s.codec)
+
+		if err != nil {
+			s.err = err
+		} else {
+			s.data = chunk.Data
+		}
+	}
+
+	n := copy(p, s.data)
+	s.data = s.data[n:]
+
+	return n, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// skipOggPacket skips lacing values and data of the first packet on the page
+func skipOggPacket(lacing, data []byte) ([]byte, []byte) {
+	for i, l := range lacing {
+		if int(l) > len(data) {
+			return nil, nil
+		}
+
+		data = data[l:]
+
+		if l < 255 {
+			return lacing[i+1:], data
+		}
+	}
+
+	return nil, nil
+}
+
+// isOggPageValid returns true if checksum of Ogg page is valid
+func isOggPageValid(page []byte) bool {
+	p := bytes.Clone(page)
+	clear(p[22:26])
+
+	return oggCRC(p) == binary.LittleEndian.Uint32(page[22:26])
+}
+
+// amplitudeToLevel converts amplitude (1.0 is full scale) to level in dBFS
+func amplitudeToLevel(amplitude float64) float64 {
+	if amplitude <= 0 {
+		return MIN_LEVEL
+	}
+
+	return min(max(20*math.Log10(amplitude), MIN_LEVEL), 0)
+}
@@ -0,0 +1,252 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"io"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// frameHeader contains info from MPEG audio or ADTS frame header
+type frameHeader struct {
+	Codec      string
+	Size       int
+	Samples    int
+	SampleRate int
+	Channels   int
+	Bitrate    int
+}
+
+// frameReader reads MPEG audio (MP1/MP2/MP3) and AAC ADTS frames
+type frameReader struct {
+	r *bufio.Reader
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mpegBitrates contains bitrates for MPEG1 and MPEG2/2.5 layers
+var mpegBitrates = [2][3][15]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+// mpegSampleRates contains sample rates for MPEG1, MPEG2 and MPEG2.5
+var mpegSampleRates = [3][3]int{
+	{44100, 48000, 32000},
+	{22050, 24000, 16000},
+	{11025, 12000, 8000},
+}
+
+// adtsSampleRates contains sample rates for ADTS
+var adtsSampleRates = []int{
+	96000, 88200, 64000, 48000, 44100, 32000, 24000,
+	22050, 16000, 12000, 11025, 8000, 7350,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FindFrame returns offset of the first MPEG audio or ADTS frame in given data.
+// Frame is considered valid if it's followed by another frame or the end of
+// data. Returns -1 if there is no frames in data.
+func FindFrame(data []byte) int {
+	for i := 0; i+7 <= len(data); i++ {
+		header := parseFrameHeader(data[i:])
+
+		if header == nil {
+			continue
+		}
+
+		next := i + header.Size
+
+		if next+7 > len(data) || parseFrameHeader(data[next:]) != nil {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads next frame. Garbage and ID3 tags between frames are skipped.
+func (r *frameReader) Read() (*Chunk, error) {
+	for {
+		data, err := r.r.Peek(10)
+
+		if err != nil {
+			if err == io.EOF || err == bufio.ErrBufferFull {
+				return nil, io.EOF
+			}
+
+			return nil, err
+		}
+
+		if string(data[:3]) == "ID3" {
+			err = r.skipID3(data)
+
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		header := parseFrameHeader(data)
+
+		if header == nil {
+			r.r.Discard(1)
+			continue
+		}
+
+		frame := make([]byte, header.Size)
+		_, err = io.ReadFull(r.r, frame)
+
+		if err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return nil, io.EOF
+			}
+
+			return nil, err
+		}
+
+		return &Chunk{
+			Data:       frame,
+			Codec:      header.Codec,
+			Duration:   time.Duration(header.Samples) * time.Second / time.Duration(header.SampleRate),
+			SampleRate: header.SampleRate,
+			Channels:   header.Channels,
+			Bitrate:    header.Bitrate,
+		}, nil
+	}
+}
+
+// skipID3 skips ID3v2 tag
+func (r *frameReader) skipID3(header []byte) error {
+	size := int(header[6]&0x7F)<<21 | int(header[7]&0x7F)<<14 |
+		int(header[8]&0x7F)<<7 | int(header[9]&0x7F)
+	size += 10
+
+	if header[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+
+	_, err := r.r.Discard(size)
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseFrameHeader parses MPEG audio or ADTS frame header
+func parseFrameHeader(data []byte) *frameHeader {
+	if len(data) < 7 || data[0] != 0xFF {
+		return nil
+	}
+
+	switch {
+	case data[1]&0xF6 == 0xF0:
+		return parseADTSHeader(data)
+	case data[1]&0xE0 == 0xE0:
+		return parseMPEGHeader(data)
+	}
+
+	return nil
+}
+
+// parseMPEGHeader parses MPEG audio frame header
+func parseMPEGHeader(data []byte) *frameHeader {
+	version := (data[1] >> 3) & 0x03 // 0 - MPEG2.5, 2 - MPEG2, 3 - MPEG1
+	layer := (data[1] >> 1) & 0x03   // 1 - Layer III, 2 - Layer II, 3 - Layer I
+	bitrateIndex := data[2] >> 4
+	sampleRateIndex := (data[2] >> 2) & 0x03
+	padding := int(data[2]>>1) & 0x01
+
+	if version == 1 || layer == 0 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return nil
+	}
+
+	h := &frameHeader{Channels: 2}
+
+	if data[3]>>6 == 3 {
+		h.Channels = 1
+	}
+
+	versionIndex := 0
+
+	switch version {
+	case 3:
+		h.SampleRate = mpegSampleRates[0][sampleRateIndex]
+	case 2:
+		h.SampleRate = mpegSampleRates[1][sampleRateIndex]
+		versionIndex = 1
+	default:
+		h.SampleRate = mpegSampleRates[2][sampleRateIndex]
+		versionIndex = 1
+	}
+
+	layerIndex := 3 - int(layer) // 0 - Layer I, 1 - Layer II, 2 - Layer III
+	h.Bitrate = mpegBitrates[versionIndex][layerIndex][bitrateIndex]
+
+	switch layerIndex {
+	case 0:
+		h.Codec = "mp1"
+		h.Samples = 384
+		h.Size = (12*h.Bitrate*1000/h.SampleRate + padding) * 4
+	case 1:
+		h.Codec = "mp2"
+		h.Samples = 1152
+		h.Size = 144*h.Bitrate*1000/h.SampleRate + padding
+	default:
+		h.Codec = "mp3"
+		h.Samples = 1152
+
+		if versionIndex == 1 {
+			h.Samples = 576
+		}
+
+		h.Size = h.Samples/8*h.Bitrate*1000/h.SampleRate + padding
+	}
+
+	return h
+}
+
+// parseADTSHeader parses AAC ADTS frame header
+func parseADTSHeader(data []byte) *frameHeader {
+	sampleRateIndex := int(data[2]>>2) & 0x0F
+
+	if sampleRateIndex >= len(adtsSampleRates) {
+		return nil
+	}
+
+	h := &frameHeader{
+		Codec:      "aac",
+		SampleRate: adtsSampleRates[sampleRateIndex],
+		Channels:   int(data[2]&0x01)<<2 | int(data[3]>>6),
+		Size:       int(data[3]&0x03)<<11 | int(data[4])<<3 | int(data[5]>>5),
+		Samples:    (int(data[6]&0x03) + 1) * 1024,
+	}
+
+	if h.Size < 7 {
+		return nil
+	}
+
+	h.Bitrate = h.Size * 8 * h.SampleRate / h.Samples / 1000
+
+	return h
+}
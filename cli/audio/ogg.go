@@ -0,0 +1,125 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// oggReader reads Ogg stream page by page
+type oggReader struct {
+	r       *bufio.Reader
+	streams map[uint32]*oggStreamInfo
+}
+
+// oggStreamInfo contains info about logical Ogg stream
+type oggStreamInfo struct {
+	Codec      string
+	SampleRate int
+	Channels   int
+	Bitrate    int
+	Granule    uint64
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// oggHeaderSize is size of Ogg page header without lacing values
+const oggHeaderSize = 27
+
+// noGranule is granule position of page without finished packets
+const noGranule = ^uint64(0)
+
+// maxOggPageDuration is max duration of Ogg page in seconds
+const maxOggPageDuration = 10
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads next Ogg page
+func (r *oggReader) Read() (*Chunk, error) {
+	page, err := stream.ReadOggPage(r.r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := r.streams[page.Serial]
+
+	if page.Flags&stream.OGG_FLAG_BOS != 0 || info == nil {
+		info = parseOggIdent(page.Data)
+		r.streams[page.Serial] = info
+	}
+
+	chunk := &Chunk{
+		Data:       page.Raw,
+		Codec:      info.Codec,
+		SampleRate: info.SampleRate,
+		Channels:   info.Channels,
+		Bitrate:    info.Bitrate,
+	}
+
+	if page.Granule != noGranule && info.SampleRate > 0 {
+		// If stream was joined in the middle (e.g. Icecast sends headers of the
+		// current logical stream to new listeners), duration of the first page
+		// can't be calculated
+		joined := info.Granule == 0 && page.Granule > uint64(info.SampleRate)*maxOggPageDuration
+
+		if page.Granule > info.Granule && !joined {
+			chunk.Duration = time.Duration(page.Granule-info.Granule) * time.Second / time.Duration(info.SampleRate)
+		}
+
+		info.Granule = page.Granule
+	}
+
+	if page.Flags&stream.OGG_FLAG_EOS != 0 {
+		delete(r.streams, page.Serial)
+	}
+
+	return chunk, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseOggIdent parses identification header of logical stream
+func parseOggIdent(data []byte) *oggStreamInfo {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x01vorbis")) && len(data) >= 28:
+		return &oggStreamInfo{
+			Codec:      "vorbis",
+			Channels:   int(data[11]),
+			SampleRate: int(binary.LittleEndian.Uint32(data[12:16])),
+			Bitrate:    int(int32(binary.LittleEndian.Uint32(data[20:24]))) / 1000,
+		}
+
+	case bytes.HasPrefix(data, []byte("OpusHead")) && len(data) >= 19:
+		// Granule position of Opus streams is always in 48 kHz samples
+		return &oggStreamInfo{
+			Codec:      "opus",
+			Channels:   int(data[9]),
+			SampleRate: 48000,
+		}
+
+	case bytes.HasPrefix(data, []byte("\x7fFLAC")) && len(data) >= 30:
+		// STREAMINFO starts after mapping header (13 bytes) and block header (4 bytes)
+		info := data[17:]
+
+		return &oggStreamInfo{
+			Codec:      "flac",
+			SampleRate: int(info[10])<<12 | int(info[11])<<4 | int(info[12]>>4),
+			Channels:   int(info[12]>>1&0x07) + 1,
+		}
+	}
+
+	return &oggStreamInfo{Codec: "unknown"}
+}
@@ -0,0 +1,106 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/binary"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OggWriter creates pages of logical Ogg stream
+type OggWriter struct {
+	Serial uint32
+
+	seq uint32
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// oggCRCTable is lookup table for Ogg CRC32 (polynomial 0x04C11DB7)
+var oggCRCTable = genOggCRCTable()
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Page creates page with single packet. First page of stream always has BOS flag.
+func (w *OggWriter) Page(packet []byte, granule uint64, eos bool) []byte {
+	var flags byte
+
+	if w.seq == 0 {
+		flags |= stream.OGG_FLAG_BOS
+	}
+
+	if eos {
+		flags |= stream.OGG_FLAG_EOS
+	}
+
+	lacing := make([]byte, 0, len(packet)/255+1)
+
+	for n := len(packet); ; n -= 255 {
+		if n < 255 {
+			lacing = append(lacing, byte(n))
+			break
+		}
+
+		lacing = append(lacing, 255)
+	}
+
+	page := make([]byte, 27, 27+len(lacing)+len(packet))
+
+	copy(page, "OggS")
+	page[5] = flags
+	binary.LittleEndian.PutUint64(page[6:], granule)
+	binary.LittleEndian.PutUint32(page[14:], w.Serial)
+	binary.LittleEndian.PutUint32(page[18:], w.seq)
+	page[26] = byte(len(lacing))
+
+	page = append(page, lacing...)
+	page = append(page, packet...)
+
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+
+	w.seq++
+
+	return page
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// oggCRC calculates CRC32 of Ogg page
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+
+	return crc
+}
+
+// genOggCRCTable generates lookup table for Ogg CRC32
+func genOggCRCTable() [256]uint32 {
+	var table [256]uint32
+
+	for i := range table {
+		crc := uint32(i) << 24
+
+		for j := 0; j < 8; j++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+
+		table[i] = crc
+	}
+
+	return table
+}
@@ -0,0 +1,45 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Pacer limits sending speed to real-time playback speed
+type Pacer struct {
+	// Lead is duration of audio which can be sent ahead of real time
+	Lead time.Duration
+
+	start time.Time
+	sent  time.Duration
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Wait waits until chunk with given duration can be sent
+func (p *Pacer) Wait(duration time.Duration) {
+	if p.start.IsZero() {
+		p.start = time.Now()
+	}
+
+	ahead := p.sent - time.Since(p.start) - p.Lead
+
+	if ahead > 0 {
+		time.Sleep(ahead)
+	}
+
+	p.sent += duration
+}
+
+// Sent returns duration of sent audio
+func (p *Pacer) Sent() time.Duration {
+	return p.sent
+}
@@ -0,0 +1,141 @@
+package audio
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"math"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	SIGNAL_TONE    = "tone"
+	SIGNAL_SWEEP   = "sweep"
+	SIGNAL_SILENCE = "silence"
+	SIGNAL_BEEP    = "beep"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Signal is test signal generator
+type Signal struct {
+	Kind       string
+	Frequency  float64
+	SampleRate int
+
+	counter int
+	pos     int
+	phase   float64
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	// signalLevel is signal level in dBFS (alignment level)
+	signalLevel = -18.0
+
+	// sweepStart is sweep start frequency in Hz
+	sweepStart = 20.0
+
+	// sweepEnd is sweep end frequency in Hz
+	sweepEnd = 20000.0
+
+	// sweepDuration is duration of one sweep in seconds
+	sweepDuration = 10.0
+
+	// beepDuration is duration of one counter beep in seconds
+	beepDuration = 0.1
+
+	// beepPeriod is period between counter beeps in seconds
+	beepPeriod = 0.25
+
+	// longBeepDuration is duration of beep for zero counter in seconds
+	longBeepDuration = 0.6
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewSignal creates new test signal generator
+func NewSignal(kind string, frequency float64, sampleRate int) (*Signal, error) {
+	switch kind {
+	case SIGNAL_TONE, SIGNAL_SWEEP, SIGNAL_SILENCE, SIGNAL_BEEP:
+	default:
+		return nil, fmt.Errorf("Unknown signal type %q", kind)
+	}
+
+	if frequency <= 0 || frequency >= float64(sampleRate)/2 {
+		return nil, fmt.Errorf("Frequency must be between 0 and %d Hz", sampleRate/2)
+	}
+
+	return &Signal{Kind: kind, Frequency: frequency, SampleRate: sampleRate}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetCounter sets counter value. For beeps signal, counter beeps start from
+// the next generated sample. Other signals are not restarted.
+func (s *Signal) SetCounter(counter int) {
+	s.counter = counter
+
+	if s.Kind == SIGNAL_BEEP {
+		s.pos = 0
+	}
+}
+
+// Generate fills buffer with signal samples
+func (s *Signal) Generate(buf []int16) {
+	amplitude := math.Pow(10, signalLevel/20) * math.MaxInt16
+
+	for i := range buf {
+		var freq float64
+
+		t := float64(s.pos) / float64(s.SampleRate)
+
+		switch s.Kind {
+		case SIGNAL_TONE:
+			freq = s.Frequency
+		case SIGNAL_SWEEP:
+			// Logarithmic sweep
+			t = math.Mod(t, sweepDuration)
+			freq = sweepStart * math.Pow(sweepEnd/sweepStart, t/sweepDuration)
+		case SIGNAL_BEEP:
+			if s.isBeep(t) {
+				freq = s.Frequency
+			}
+		}
+
+		if freq == 0 {
+			buf[i] = 0
+			s.phase = 0
+		} else {
+			buf[i] = int16(amplitude * math.Sin(s.phase))
+			s.phase = math.Mod(s.phase+2*math.Pi*freq/float64(s.SampleRate), 2*math.Pi)
+		}
+
+		s.pos++
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isBeep returns true if beep must be played at given time. Number of beeps
+// is equal to the last digit of counter, zero is coded as one long beep.
+func (s *Signal) isBeep(t float64) bool {
+	beeps := s.counter % 10
+
+	if beeps == 0 {
+		return t < longBeepDuration
+	}
+
+	if t >= float64(beeps)*beepPeriod {
+		return false
+	}
+
+	return math.Mod(t, beepPeriod) < beepDuration
+}
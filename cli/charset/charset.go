@@ -0,0 +1,185 @@
+package charset
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Charset is single-byte charset used for metadata encoding
+type Charset struct {
+	Name string // IANA name of charset
+
+	charmap *charmap.Charmap
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UTF8 is UTF-8 charset
+var UTF8 = &Charset{Name: "UTF-8"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// charsets is map with supported charsets
+var charsets = map[string]*Charset{
+	"utf8":        UTF8,
+	"latin1":      {"ISO-8859-1", charmap.ISO8859_1},
+	"iso88591":    {"ISO-8859-1", charmap.ISO8859_1},
+	"latin2":      {"ISO-8859-2", charmap.ISO8859_2},
+	"iso88592":    {"ISO-8859-2", charmap.ISO8859_2},
+	"iso88595":    {"ISO-8859-5", charmap.ISO8859_5},
+	"latin9":      {"ISO-8859-15", charmap.ISO8859_15},
+	"iso885915":   {"ISO-8859-15", charmap.ISO8859_15},
+	"cp1250":      {"windows-1250", charmap.Windows1250},
+	"windows1250": {"windows-1250", charmap.Windows1250},
+	"cp1251":      {"windows-1251", charmap.Windows1251},
+	"windows1251": {"windows-1251", charmap.Windows1251},
+	"cp1252":      {"windows-1252", charmap.Windows1252},
+	"windows1252": {"windows-1252", charmap.Windows1252},
+	"koi8r":       {"KOI8-R", charmap.KOI8R},
+	"koi8u":       {"KOI8-U", charmap.KOI8U},
+}
+
+// translitTable contains latin replacements for symbols which can't be
+// decomposed using unicode normalization
+var translitTable = map[rune]string{
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "Yo",
+	'Ж': "Zh", 'З': "Z", 'И': "I", 'Й': "Y", 'К': "K", 'Л': "L", 'М': "M",
+	'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U",
+	'Ф': "F", 'Х': "Kh", 'Ц': "Ts", 'Ч': "Ch", 'Ш': "Sh", 'Щ': "Shch",
+	'Ъ': "", 'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'Є': "Ye", 'І': "I", 'Ї': "Yi", 'Ґ': "G", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+	'‘': "'", '’': "'", '‚': ",", '“': "\"", '”': "\"", '„': "\"", '«': "\"", '»': "\"",
+	'–': "-", '—': "-", '…': "...", '•': "*", '×': "x", '№': "No",
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'Ø': "O", 'ø': "o",
+	'Ł': "L", 'ł': "l", 'Đ': "D", 'đ': "d", 'Þ': "Th", 'þ': "th",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns charset with given name
+func Get(name string) (*Charset, error) {
+	key := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+	c, ok := charsets[key]
+
+	if !ok {
+		return nil, fmt.Errorf("Unsupported charset %q", name)
+	}
+
+	return c, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Encode encodes string to charset. Symbols which are not supported by charset
+// are transliterated (if translit is true) or replaced by question mark.
+func (c *Charset) Encode(s string, translit bool) string {
+	if c == nil || c.charmap == nil {
+		return s
+	}
+
+	var result strings.Builder
+
+	for _, r := range s {
+		b, ok := c.charmap.EncodeRune(r)
+
+		if ok {
+			result.WriteByte(b)
+			continue
+		}
+
+		if !translit {
+			result.WriteByte('?')
+			continue
+		}
+
+		for _, tr := range Transliterate(string(r)) {
+			b, ok = c.charmap.EncodeRune(tr)
+
+			if ok {
+				result.WriteByte(b)
+			} else {
+				result.WriteByte('?')
+			}
+		}
+	}
+
+	return result.String()
+}
+
+// Truncate truncates encoded string to given size in bytes
+func (c *Charset) Truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+
+	if size <= 0 {
+		return ""
+	}
+
+	if c != nil && c.charmap != nil {
+		return s[:size]
+	}
+
+	// Don't break multibyte symbols in UTF-8 strings
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+
+	return s[:size]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Transliterate converts string to latin symbols
+func Transliterate(s string) string {
+	var result strings.Builder
+
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			result.WriteRune(r)
+			continue
+		}
+
+		if tr, ok := translitTable[r]; ok {
+			result.WriteString(tr)
+			continue
+		}
+
+		result.WriteString(removeMarks(string(r)))
+	}
+
+	return result.String()
+}
+
+// removeMarks removes diacritical marks from symbols (é → e)
+func removeMarks(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+
+	if err != nil {
+		return s
+	}
+
+	return result
+}
@@ -9,6 +9,7 @@ package cli
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -17,7 +18,9 @@ import (
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fmtutil"
 	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/log"
 	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/strutil"
 	"github.com/essentialkaos/ek/v13/support"
 	"github.com/essentialkaos/ek/v13/support/deps"
 	"github.com/essentialkaos/ek/v13/support/pkgs"
@@ -32,6 +35,9 @@ import (
 	"github.com/essentialkaos/ek/v13/usage/update"
 
 	ic "github.com/essentialkaos/go-icecast/v3"
+
+	"github.com/essentialkaos/icecli/cli/source"
+	"github.com/essentialkaos/icecli/cli/stream"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -53,6 +59,27 @@ const (
 	CMD_LIST_MOUNTS  = "list-mounts"
 	CMD_MOVE_CLIENTS = "move-clients"
 	CMD_UPDATE_META  = "update-meta"
+	CMD_ABUSERS      = "abusers"
+	CMD_GEO          = "geo"
+	CMD_PLAYERS      = "players"
+	CMD_REAP         = "reap"
+	CMD_SNAPSHOT     = "snapshot"
+	CMD_META_WATCH   = "meta-watch"
+	CMD_MPD_SYNC     = "mpd-sync"
+	CMD_META_HISTORY = "meta-history"
+	CMD_SCHEDULE     = "schedule"
+
+	CMD_SERVE_NOWPLAYING = "serve-nowplaying"
+	CMD_LISTEN_META      = "listen-meta"
+	CMD_VERIFY_META      = "verify-meta"
+	CMD_PUSH             = "push"
+	CMD_PLAYLIST_SOURCE  = "playlist-source"
+	CMD_TEST_SOURCE      = "test-source"
+	CMD_RECORD_STREAM    = "record-stream"
+	CMD_LOGGER           = "logger"
+	CMD_EXTRACT          = "extract"
+	CMD_PROBE            = "probe"
+	CMD_DEAD_AIR         = "dead-air"
 )
 
 const (
@@ -63,6 +90,60 @@ const (
 	OPT_HELP     = "h:help"
 	OPT_VER      = "v:version"
 
+	OPT_MAX_CONNS      = "max-conns"
+	OPT_RECONNECT_TIME = "reconnect-time"
+	OPT_YES            = "y:yes"
+	OPT_GEOIP          = "geoip"
+	OPT_UA_INFO        = "ua-info"
+	OPT_UA_RULES       = "ua-rules"
+	OPT_GROUP_BY       = "group-by"
+	OPT_MAX_LAG        = "max-lag"
+	OPT_GRACE          = "grace"
+	OPT_INTERVAL       = "i:interval"
+	OPT_DRY_RUN        = "D:dry-run"
+	OPT_LOG            = "L:log"
+	OPT_STDIN          = "stdin"
+	OPT_FIELDS         = "F:fields"
+	OPT_MPD            = "mpd"
+	OPT_CHARSET        = "charset"
+	OPT_TRANSLIT       = "translit"
+	OPT_SONG           = "song"
+	OPT_ALBUM          = "album"
+	OPT_ARTWORK        = "artwork"
+	OPT_META_RULES     = "meta-rules"
+	OPT_FROM           = "from"
+	OPT_TO             = "to"
+	OPT_FORMAT         = "f:format"
+	OPT_GROUPS         = "G:groups"
+	OPT_LISTEN         = "listen"
+	OPT_CORS           = "cors"
+	OPT_SSE            = "sse"
+	OPT_TIMEOUT        = "T:timeout"
+	OPT_MAX_AGE        = "max-age"
+	OPT_SOURCE_USER    = "source-user"
+	OPT_SOURCE_PASS    = "source-password"
+	OPT_CONTENT_TYPE   = "content-type"
+	OPT_BITRATE        = "bitrate"
+	OPT_STREAM_NAME    = "stream-name"
+	OPT_STREAM_DESC    = "stream-description"
+	OPT_STREAM_GENRE   = "stream-genre"
+	OPT_STREAM_URL     = "stream-url"
+	OPT_PUBLIC         = "public"
+	OPT_SHUFFLE        = "shuffle"
+	OPT_LOOP           = "loop"
+	OPT_SIGNAL         = "signal"
+	OPT_FREQUENCY      = "frequency"
+	OPT_OUTPUT         = "o:output"
+	OPT_TEMPLATE       = "template"
+	OPT_SPLIT_TIME     = "split-time"
+	OPT_SPLIT_SIZE     = "split-size"
+	OPT_SPLIT_META     = "split-meta"
+	OPT_SEGMENT        = "segment"
+	OPT_RETENTION      = "retention"
+	OPT_DURATION       = "duration"
+	OPT_SILENCE_LEVEL  = "silence-level"
+	OPT_SILENCE_TIME   = "silence-time"
+
 	OPT_VERB_VER     = "vv:verbose-version"
 	OPT_COMPLETION   = "completion"
 	OPT_GENERATE_MAN = "generate-man"
@@ -70,6 +151,14 @@ const (
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// mountClient is listener connected to some mount point
+type mountClient struct {
+	Mount string
+	*ic.Listener
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // optMap is map with options
 var optMap = options.Map{
 	OPT_HOST:     {Value: "http://127.0.0.1:8000", Alias: "url"},
@@ -79,6 +168,60 @@ var optMap = options.Map{
 	OPT_HELP:     {Type: options.BOOL},
 	OPT_VER:      {Type: options.MIXED},
 
+	OPT_MAX_CONNS:      {Type: options.INT, Value: 5, Min: 1, Max: 10000},
+	OPT_RECONNECT_TIME: {Value: "15s"},
+	OPT_YES:            {Type: options.BOOL},
+	OPT_GEOIP:          {},
+	OPT_UA_INFO:        {Type: options.BOOL},
+	OPT_UA_RULES:       {},
+	OPT_GROUP_BY:       {Value: "player"},
+	OPT_MAX_LAG:        {Value: "1MB"},
+	OPT_GRACE:          {Value: "30s"},
+	OPT_INTERVAL:       {Value: "5s"},
+	OPT_DRY_RUN:        {Type: options.BOOL},
+	OPT_LOG:            {},
+	OPT_STDIN:          {Type: options.BOOL},
+	OPT_FIELDS:         {},
+	OPT_MPD:            {Value: "127.0.0.1:6600"},
+	OPT_CHARSET:        {},
+	OPT_TRANSLIT:       {Type: options.BOOL},
+	OPT_SONG:           {},
+	OPT_ALBUM:          {},
+	OPT_ARTWORK:        {},
+	OPT_META_RULES:     {},
+	OPT_FROM:           {},
+	OPT_TO:             {},
+	OPT_FORMAT:         {Value: FORMAT_TABLE},
+	OPT_GROUPS:         {},
+	OPT_LISTEN:         {Value: "127.0.0.1:8080"},
+	OPT_CORS:           {Value: "*"},
+	OPT_SSE:            {Type: options.BOOL},
+	OPT_TIMEOUT:        {Value: "10s"},
+	OPT_MAX_AGE:        {},
+	OPT_SOURCE_USER:    {Value: "source"},
+	OPT_SOURCE_PASS:    {Value: "hackme"},
+	OPT_CONTENT_TYPE:   {},
+	OPT_BITRATE:        {Type: options.INT, Min: 1, Max: 10000},
+	OPT_STREAM_NAME:    {},
+	OPT_STREAM_DESC:    {},
+	OPT_STREAM_GENRE:   {},
+	OPT_STREAM_URL:     {},
+	OPT_PUBLIC:         {Type: options.BOOL},
+	OPT_SHUFFLE:        {Type: options.BOOL},
+	OPT_LOOP:           {Type: options.BOOL},
+	OPT_SIGNAL:         {Value: "tone"},
+	OPT_FREQUENCY:      {Type: options.INT, Value: 1000, Min: 1, Max: 20000},
+	OPT_OUTPUT:         {Value: "."},
+	OPT_TEMPLATE:       {},
+	OPT_SPLIT_TIME:     {},
+	OPT_SPLIT_SIZE:     {},
+	OPT_SPLIT_META:     {Type: options.BOOL},
+	OPT_SEGMENT:        {Value: "15m"},
+	OPT_RETENTION:      {Value: "90d"},
+	OPT_DURATION:       {Value: "10s"},
+	OPT_SILENCE_LEVEL:  {Type: options.INT, Value: -50, Min: -120, Max: 0},
+	OPT_SILENCE_TIME:   {Value: "30s"},
+
 	OPT_VERB_VER:     {Type: options.BOOL},
 	OPT_COMPLETION:   {},
 	OPT_GENERATE_MAN: {Type: options.BOOL},
@@ -170,7 +313,7 @@ func execCommand(args options.Arguments) {
 	var err error
 
 	client, err = ic.NewAPI(
-		options.GetS(OPT_HOST),
+		getHostURL(),
 		options.GetS(OPT_USER),
 		options.GetS(OPT_PASS),
 	)
@@ -179,6 +322,15 @@ func execCommand(args options.Arguments) {
 		printErrorExit(err.Error())
 	}
 
+	stream.UserAgent = APP + "/" + VER
+	source.UserAgent = APP + "/" + VER
+
+	err = initMetaRules()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
 	cmd := args.Get(0).ToLower().String()
 
 	switch cmd {
@@ -196,7 +348,18 @@ func execCommand(args options.Arguments) {
 			args.Get(2).String(),
 		)
 	case CMD_UPDATE_META:
-		checkForRequiredArgs(args, 3)
+		if options.GetB(OPT_STDIN) {
+			checkForRequiredArgs(args, 1)
+			readMetaFromStdin(args.Get(1).String())
+			break
+		}
+
+		if !options.Has(OPT_SONG) {
+			checkForRequiredArgs(args, 3)
+		} else {
+			checkForRequiredArgs(args, 1)
+		}
+
 		updateMeta(
 			args.Get(1).String(),
 			args.Get(2).String(),
@@ -206,11 +369,65 @@ func execCommand(args options.Arguments) {
 		checkForRequiredArgs(args, 2)
 		killClient(
 			args.Get(1).String(),
-			args.Get(2).String(),
+			args[2:].Strings(),
 		)
 	case CMD_KILL_SOURCE:
 		checkForRequiredArgs(args, 1)
 		killSource(args.Get(1).String())
+	case CMD_ABUSERS:
+		findAbusers()
+	case CMD_GEO:
+		showGeoStats()
+	case CMD_PLAYERS:
+		showPlayers(args.Get(1).String())
+	case CMD_REAP:
+		startReaper(args.Get(1).String())
+	case CMD_SNAPSHOT:
+		processSnapshotCommand(args)
+	case CMD_META_WATCH:
+		checkForRequiredArgs(args, 2)
+		watchMetaFile(
+			args.Get(1).String(),
+			args.Get(2).String(),
+		)
+	case CMD_MPD_SYNC:
+		checkForRequiredArgs(args, 1)
+		startMPDSync(args[1:].Strings())
+	case CMD_META_HISTORY:
+		processMetaHistoryCommand(args)
+	case CMD_SCHEDULE:
+		checkForRequiredArgs(args, 2)
+		startScheduler(args.Get(1).String(), args[2:].Strings())
+	case CMD_SERVE_NOWPLAYING:
+		serveNowPlaying()
+	case CMD_LISTEN_META:
+		checkForRequiredArgs(args, 1)
+		listenMeta(args.Get(1).String())
+	case CMD_VERIFY_META:
+		verifyMeta(args.Get(1).String())
+	case CMD_PUSH:
+		checkForRequiredArgs(args, 2)
+		push(args.Get(1).String(), args.Get(2).String())
+	case CMD_PLAYLIST_SOURCE:
+		checkForRequiredArgs(args, 2)
+		startPlaylistSource(args.Get(1).String(), args.Get(2).String())
+	case CMD_TEST_SOURCE:
+		checkForRequiredArgs(args, 1)
+		startTestSource(args.Get(1).String())
+	case CMD_RECORD_STREAM:
+		checkForRequiredArgs(args, 1)
+		recordStream(args.Get(1).String())
+	case CMD_LOGGER:
+		checkForRequiredArgs(args, 2)
+		startLogger(args.Get(1).String(), args[2:].Strings())
+	case CMD_EXTRACT:
+		checkForRequiredArgs(args, 3)
+		extract(args.Get(1).String(), args.Get(2).String(), args.Get(3).String())
+	case CMD_PROBE:
+		checkForRequiredArgs(args, 1)
+		probe(args.Get(1).String())
+	case CMD_DEAD_AIR:
+		startDeadAirMonitor(args.Get(1).String())
 	default:
 		terminal.Error("Unknown or unsupported command %q", cmd)
 		os.Exit(1)
@@ -234,6 +451,46 @@ func showHelp(command string) {
 		helpCmdKillClient()
 	case CMD_KILL_SOURCE:
 		helpCmdKillSource()
+	case CMD_ABUSERS:
+		helpCmdAbusers()
+	case CMD_GEO:
+		helpCmdGeo()
+	case CMD_PLAYERS:
+		helpCmdPlayers()
+	case CMD_REAP:
+		helpCmdReap()
+	case CMD_SNAPSHOT:
+		helpCmdSnapshot()
+	case CMD_META_WATCH:
+		helpCmdMetaWatch()
+	case CMD_MPD_SYNC:
+		helpCmdMPDSync()
+	case CMD_META_HISTORY:
+		helpCmdMetaHistory()
+	case CMD_SCHEDULE:
+		helpCmdSchedule()
+	case CMD_SERVE_NOWPLAYING:
+		helpCmdServeNowPlaying()
+	case CMD_LISTEN_META:
+		helpCmdListenMeta()
+	case CMD_VERIFY_META:
+		helpCmdVerifyMeta()
+	case CMD_PUSH:
+		helpCmdPush()
+	case CMD_PLAYLIST_SOURCE:
+		helpCmdPlaylistSource()
+	case CMD_TEST_SOURCE:
+		helpCmdTestSource()
+	case CMD_RECORD_STREAM:
+		helpCmdRecordStream()
+	case CMD_LOGGER:
+		helpCmdLogger()
+	case CMD_EXTRACT:
+		helpCmdExtract()
+	case CMD_PROBE:
+		helpCmdProbe()
+	case CMD_DEAD_AIR:
+		helpCmdDeadAir()
 	default:
 		genUsage().Print()
 	}
@@ -387,18 +644,66 @@ func listClients(mount string) {
 		return
 	}
 
-	t := table.NewTable("id", "ip", "lag", "connected", "user-agent")
-	t.SetAlignments(table.ALIGN_RIGHT, table.ALIGN_RIGHT, table.ALIGN_RIGHT, table.ALIGN_RIGHT)
-	t.SetSizes(6, 14, 10, 9)
+	withGeo := options.Has(OPT_GEOIP)
+	withUA := options.GetB(OPT_UA_INFO)
+
+	if withGeo {
+		err = openGeoDB()
+
+		if err != nil {
+			printErrorExit(err.Error())
+		}
+	}
+
+	if withUA {
+		err = initUAParser()
+
+		if err != nil {
+			printErrorExit(err.Error())
+		}
+	}
+
+	headers := []string{"id", "ip"}
+	aligns := []uint8{table.ALIGN_RIGHT, table.ALIGN_RIGHT}
+	sizes := []int{6, 14}
+
+	if withGeo {
+		headers = append(headers, "country", "city")
+		aligns = append(aligns, table.ALIGN_LEFT, table.ALIGN_LEFT)
+		sizes = append(sizes, 16, 14)
+	}
+
+	headers = append(headers, "lag", "connected")
+	aligns = append(aligns, table.ALIGN_RIGHT, table.ALIGN_RIGHT)
+	sizes = append(sizes, 10, 9)
+
+	if withUA {
+		headers = append(headers, "client")
+		aligns = append(aligns, table.ALIGN_LEFT)
+		sizes = append(sizes, 32)
+	}
+
+	t := table.NewTable(append(headers, "user-agent")...)
+	t.SetAlignments(aligns...)
+	t.SetSizes(sizes...)
 
 	fmtc.NewLine()
 
 	for _, l := range listeners {
-		t.Print(
-			l.ID, l.IP, fmtutil.PrettySize(l.Lag),
-			timeutil.ShortDuration(l.Connected),
-			l.UserAgent,
-		)
+		row := []any{l.ID, l.IP}
+
+		if withGeo {
+			country, city := lookupGeo(l.IP)
+			row = append(row, country, formatString(city))
+		}
+
+		row = append(row, fmtutil.PrettySize(l.Lag), timeutil.ShortDuration(l.Connected))
+
+		if withUA {
+			row = append(row, uaParser.Parse(l.UserAgent).String())
+		}
+
+		t.Print(append(row, l.UserAgent)...)
 	}
 
 	t.Separator()
@@ -406,6 +711,56 @@ func listClients(mount string) {
 	fmtc.NewLine()
 }
 
+// listAllClients returns clients connected to all mount points
+func listAllClients() ([]*mountClient, error) {
+	mounts, err := client.ListMounts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*mountClient
+
+	for _, m := range mounts {
+		clients, err := listMountClients(m.Path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, clients...)
+	}
+
+	return result, nil
+}
+
+// listMountsClients returns clients connected to given mount point or
+// to all mount points if mount is empty
+func listMountsClients(mount string) ([]*mountClient, error) {
+	if mount == "" {
+		return listAllClients()
+	}
+
+	return listMountClients(mount)
+}
+
+// listMountClients returns clients connected to given mount point
+func listMountClients(mount string) ([]*mountClient, error) {
+	listeners, err := client.ListClients(mount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*mountClient
+
+	for _, l := range listeners {
+		result = append(result, &mountClient{mount, l})
+	}
+
+	return result, nil
+}
+
 // moveClients moves clients from one mount point to another
 func moveClients(fromMount, toMount string) {
 	fromMount = formatMount(fromMount)
@@ -420,38 +775,158 @@ func moveClients(fromMount, toMount string) {
 	fmtc.Printfn("{g}Clients successfully moved from %s to %s{!}", fromMount, toMount)
 }
 
-// updateMeta updates metadata for given mount point
-func updateMeta(mount, artist, title string) {
-	mount = formatMount(mount)
-
-	err := client.UpdateMeta(mount, ic.TrackMeta{
-		Artist: artist,
-		Title:  title,
-	})
+// updateMeta updates metadata for given mount points
+func updateMeta(target, artist, title string) {
+	mounts, err := resolveMounts(target)
 
 	if err != nil {
 		printErrorExit(err.Error())
 	}
 
-	fmtc.Printfn("{g}Metadata successfully updated for %s{!}", mount)
+	meta := ic.TrackMeta{
+		Song:    options.GetS(OPT_SONG),
+		Artist:  artist,
+		Title:   title,
+		Album:   options.GetS(OPT_ALBUM),
+		Artwork: options.GetS(OPT_ARTWORK),
+	}
+
+	if !isMountsTarget(target) {
+		err = sendMeta(mounts[0], meta)
+
+		if err != nil {
+			printErrorExit(err.Error())
+		}
+
+		fmtc.Printfn("{g}Metadata successfully updated for %s{!}", mounts[0])
+		return
+	}
+
+	hasErrors := false
+
+	for _, res := range sendMetaToMounts(mounts, meta) {
+		if res.Err != nil {
+			terminal.Error("Can't update metadata for %s: %v", res.Mount, res.Err)
+			hasErrors = true
+			continue
+		}
+
+		fmtc.Printfn("{g}Metadata successfully updated for %s{!}", res.Mount)
+	}
+
+	if hasErrors {
+		os.Exit(1)
+	}
 }
 
-// killClient detaches client with given ID from the mount point
-func killClient(mount, clientID string) {
+// killClient detaches clients with given IDs, IDs ranges or IPs from the
+// mount point
+func killClient(mount string, targets []string) {
 	mount = formatMount(mount)
-	id, err := strconv.Atoi(clientID)
+	ids, err := resolveClientIDs(mount, targets)
 
 	if err != nil {
 		printErrorExit(err.Error())
 	}
 
-	err = client.KillClient(mount, id)
+	if len(ids) == 0 {
+		printErrorExit("No clients found on %s for given targets", mount)
+	}
+
+	hasErrors := false
 
-	if err != nil {
-		printErrorExit(err.Error())
+	for _, id := range ids {
+		err = client.KillClient(mount, id)
+
+		if err != nil {
+			terminal.Error("Can't detach client %d from %s: %v", id, mount, err)
+			hasErrors = true
+			continue
+		}
+
+		fmtc.Printfn("{g}Client %d successfully detached from %s{!}", id, mount)
+	}
+
+	if hasErrors {
+		os.Exit(1)
+	}
+}
+
+// resolveClientIDs converts list of IDs, IDs ranges and IPs to list of client IDs.
+// Ranges and IPs are resolved using the list of clients connected to the mount point.
+func resolveClientIDs(mount string, targets []string) ([]int, error) {
+	var ids []int
+	var listeners []*ic.Listener
+
+	added := map[int]bool{}
+
+	for _, target := range strings.Split(strings.Join(targets, ","), ",") {
+		target = strings.TrimSpace(target)
+
+		if target == "" {
+			continue
+		}
+
+		var matcher func(l *ic.Listener) bool
+
+		switch {
+		case net.ParseIP(target) != nil:
+			matcher = func(l *ic.Listener) bool { return l.IP == target }
+
+		case strings.Contains(target, "-"):
+			from, errFrom := strconv.Atoi(strutil.Before(target, "-"))
+			to, errTo := strconv.Atoi(strutil.After(target, "-"))
+
+			if errFrom != nil || errTo != nil || from > to {
+				return nil, fmt.Errorf("Invalid client IDs range %q", target)
+			}
+
+			matcher = func(l *ic.Listener) bool { return l.ID >= from && l.ID <= to }
+
+		default:
+			id, err := strconv.Atoi(target)
+
+			if err != nil {
+				return nil, fmt.Errorf("Invalid client ID %q", target)
+			}
+
+			if !added[id] {
+				ids, added[id] = append(ids, id), true
+			}
+
+			continue
+		}
+
+		if listeners == nil {
+			var err error
+
+			listeners, err = client.ListClients(mount)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		found := false
+
+		for _, l := range listeners {
+			if !matcher(l) {
+				continue
+			}
+
+			found = true
+
+			if !added[l.ID] {
+				ids, added[l.ID] = append(ids, l.ID), true
+			}
+		}
+
+		if !found {
+			terminal.Warn("No clients found on %s for %q", mount, target)
+		}
 	}
 
-	fmtc.Printfn("{g}Cliend %d successfully detached from %s{!}", id, mount)
+	return ids, nil
 }
 
 // killSource detaches source from given mount point
@@ -467,6 +942,35 @@ func killSource(mount string) {
 	fmtc.Printfn("{g}Source successfully detached from %s{!}", mount)
 }
 
+// setupLogger configures logger for long-running commands
+func setupLogger() error {
+	if !options.Has(OPT_LOG) {
+		return nil
+	}
+
+	err := log.Set(options.GetS(OPT_LOG), 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't setup logger: %w", err)
+	}
+
+	return nil
+}
+
+// parseInterval parses interval option
+func parseInterval() (time.Duration, error) {
+	interval, err := timeutil.ParseDuration(options.GetS(OPT_INTERVAL))
+
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("Can't parse %s value: %w", options.F(OPT_INTERVAL), err)
+	case interval < time.Second:
+		return 0, fmt.Errorf("Interval must be at least 1 second")
+	}
+
+	return interval, nil
+}
+
 // printServerHeader prints header with icecast info
 func printServerHeader(id string) {
 	showSeparator(false)
@@ -500,6 +1004,26 @@ func formatString(s string) string {
 	return s
 }
 
+// formatMountFilter formats mount name used as a filter
+func formatMountFilter(mount string) string {
+	if mount == "" {
+		return "all"
+	}
+
+	return mount
+}
+
+// getHostURL returns URL of Icecast instance with default scheme
+func getHostURL() string {
+	host := strings.TrimRight(options.GetS(OPT_HOST), "/")
+
+	if !strings.Contains(host, "://") {
+		return "http://" + host
+	}
+
+	return host
+}
+
 // formatMount formats mount name
 func formatMount(mount string) string {
 	if !strings.HasPrefix(mount, "/") {
@@ -565,9 +1089,16 @@ func helpCmdListClients() {
 	fmtc.Println("{*}Arguments:{!}\n")
 	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
 	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-10s{!} - Path to MaxMind database for country and city columns", options.F(OPT_GEOIP))
+	fmtc.Printfn("  {g}%-10s{!} - Show client info parsed from user agent", options.F(OPT_UA_INFO))
+	fmtc.Printfn("  {g}%-10s{!} - Path to file with custom user agent rules", options.F(OPT_UA_RULES))
+	fmtc.NewLine()
 	fmtc.Println("{*}Examples:{!}\n")
 	fmtc.Printfn("  %s %s /source1.ogg", APP, CMD_LIST_CLIENTS)
 	fmtc.Printfn("  %s %s source1.ogg", APP, CMD_LIST_CLIENTS)
+	fmtc.Printfn("  %s %s %s GeoLite2-City.mmdb source1.ogg", APP, CMD_LIST_CLIENTS, options.F(OPT_GEOIP))
+	fmtc.Printfn("  %s %s %s source1.ogg", APP, CMD_LIST_CLIENTS, options.F(OPT_UA_INFO))
 	fmtc.NewLine()
 }
 
@@ -598,16 +1129,64 @@ func helpCmdUpdateMeta() {
 	fmtc.Println("  This command provides the ability for either a source client or any external")
 	fmtc.Println("  program to update the metadata information for a particular mountpoint.")
 	fmtc.NewLine()
+	printMountTargetsHelp()
+	fmtc.Println("  With --stdin option, metadata is read line by line from standard input. Every")
+	fmtc.Println("  line must contain \"artist - title\" or JSON object with \"artist\" and \"title\"")
+	fmtc.Println("  (or \"song\") fields and optional \"album\" and \"artwork\" fields. Metadata is")
+	fmtc.Println("  updated only when track is changed.")
+	fmtc.NewLine()
+	fmtc.Println("  With --song option, raw song value is sent instead of artist and title.")
+	fmtc.Println("  Arbitrary extra fields {s-}(e.g. custom comments of Ogg/Opus mountpoints){!} are")
+	fmtc.Println("  not supported, because Icecast ignores unknown fields in metadata updates.")
+	fmtc.NewLine()
+	fmtc.Println("  With --charset option, metadata is converted to given charset for legacy")
+	fmtc.Println("  mountpoints which expect Latin-1, CP1251 and other single-byte charsets.")
+	fmtc.Println("  Unsupported symbols are replaced by question mark or transliterated with")
+	fmtc.Println("  --translit option. Metadata longer than ICY metadata limit is truncated.")
+	fmtc.NewLine()
+	fmtc.Println("  With --meta-rules option, metadata is normalized before sending using rules")
+	fmtc.Println("  from the file. Every line of the file contains \"action | field | value\" rule,")
+	fmtc.Println("  where field is song, artist, title, album or * for all of them:")
+	fmtc.NewLine()
+	fmtc.Println("    {s}replace   | title  | (?i)\\bfeat\\.? => ft.{!}      {s-}— Replace regex matches{!}")
+	fmtc.Println("    {s}strip     | *      | (?i)\\s*\\(radio edit\\){!}   {s-}— Remove regex matches{!}")
+	fmtc.Println("    {s}case      | artist | title{!}                  {s-}— Change case (title/sentence/upper/lower){!}")
+	fmtc.Println("    {s}blacklist | title  | jingle{!}                 {s-}— Clear field if it contains the word{!}")
+	fmtc.Println("    {s}fallback  | title  | Radio Example{!}          {s-}— Use value if title or artist is empty{!}")
+	fmtc.NewLine()
+	fmtc.Println("  Rules are applied from top to bottom, fallback rules are applied last.")
+	fmtc.Println("  Note that if song is not empty, Icecast uses it instead of artist and title.")
+	fmtc.NewLine()
 	fmtc.Println("{*}Usage:{!}\n")
 	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount artist title{!}", APP, CMD_UPDATE_META)
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {s}%s song{!} {g}mount{!}", APP, CMD_UPDATE_META, options.F(OPT_SONG))
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {s}%s{!} {g}mount{!}", APP, CMD_UPDATE_META, options.F(OPT_STDIN))
 	fmtc.NewLine()
 	fmtc.Println("{*}Arguments:{!}\n")
-	fmtc.Println("  {g}mount {!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.Println("  {g}mount {!} - Mount name, list, glob or group {s-}(with or without leading slash){!}")
 	fmtc.Println("  {g}artist{!} - Track artist name")
 	fmtc.Println("  {g}title {!} - Track title")
 	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-10s{!} - Raw song value {s-}(used instead of artist and title){!}", options.F(OPT_SONG))
+	fmtc.Printfn("  {g}%-10s{!} - Album name", options.F(OPT_ALBUM))
+	fmtc.Printfn("  {g}%-10s{!} - Artwork URL", options.F(OPT_ARTWORK))
+	fmtc.Printfn("  {g}%-10s{!} - Read metadata from standard input", options.F(OPT_STDIN))
+	fmtc.Printfn("  {g}%-10s{!} - Metadata charset {s-}(latin1/latin2/latin9/cp1250/cp1251/cp1252/koi8-r/koi8-u){!}", options.F(OPT_CHARSET))
+	fmtc.Printfn("  {g}%-10s{!} - Transliterate symbols not supported by charset", options.F(OPT_TRANSLIT))
+	fmtc.Printfn("  {g}%-10s{!} - Path to file with metadata normalization rules", options.F(OPT_META_RULES))
+	fmtc.Printfn("  {g}%-10s{!} - Path to file with mount groups", options.F(OPT_GROUPS))
+	fmtc.Printfn("  {g}%-10s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
 	fmtc.Println("{*}Examples:{!}\n")
-	fmtc.Printfn("  %s %s \"Wretch 32\" \"Traktor (Brookes Brothers Remix)\"", APP, CMD_UPDATE_META)
+	fmtc.Printfn("  %s %s /source1.ogg \"Wretch 32\" \"Traktor (Brookes Brothers Remix)\"", APP, CMD_UPDATE_META)
+	fmtc.Printfn("  %s %s \"/jazz*\" \"Miles Davis\" \"So What\"", APP, CMD_UPDATE_META)
+	fmtc.Printfn("  %s %s %s groups.txt @jazz \"Miles Davis\" \"So What\"", APP, CMD_UPDATE_META, options.F(OPT_GROUPS))
+	fmtc.Printfn("  %s %s %s cp1251 /source1.mp3 \"Кино\" \"Группа крови\"", APP, CMD_UPDATE_META, options.F(OPT_CHARSET))
+	fmtc.Printfn("  %s %s %s latin1 %s /source1.mp3 \"Мумий Тролль\" \"Утекай\"", APP, CMD_UPDATE_META, options.F(OPT_CHARSET), options.F(OPT_TRANSLIT))
+	fmtc.Printfn("  %s %s %s \"Morning Show with Ann\" /source1.ogg", APP, CMD_UPDATE_META, options.F(OPT_SONG))
+	fmtc.Printfn("  %s %s %s \"Hardwired\" %s https://cdn.example.com/covers/1.jpg /source1.ogg Metallica \"Moth Into Flame\"", APP, CMD_UPDATE_META, options.F(OPT_ALBUM), options.F(OPT_ARTWORK))
+	fmtc.Printfn("  playout-now-playing | %s %s %s /source1.ogg", APP, CMD_UPDATE_META, options.F(OPT_STDIN))
 	fmtc.NewLine()
 }
 
@@ -615,18 +1194,22 @@ func helpCmdUpdateMeta() {
 func helpCmdKillClient() {
 	fmtc.NewLine()
 	fmtc.Println("{*}Description:{!}\n")
-	fmtc.Println("  Disconnects a specific listener of a currently connected mountpoint.")
+	fmtc.Println("  Disconnects specific listeners of a currently connected mountpoint. Listeners")
+	fmtc.Println("  can be set by ID, IDs range or IP address. Ranges and IP addresses are resolved")
+	fmtc.Println("  to client IDs using the list of clients connected to the mountpoint.")
 	fmtc.NewLine()
 	fmtc.Println("{*}Usage:{!}\n")
-	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_KILL_CLIENT)
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount client-id…{!}", APP, CMD_KILL_CLIENT)
 	fmtc.NewLine()
 	fmtc.Println("{*}Arguments:{!}\n")
 	fmtc.Println("  {g}mount    {!} - Mount name {s-}(with or without leading slash){!}")
-	fmtc.Println("  {g}client-id{!} - Client ID")
+	fmtc.Println("  {g}client-id{!} - Client ID, IDs range or IP {s-}(several values can be separated by comma){!}")
 	fmtc.NewLine()
 	fmtc.Println("{*}Examples:{!}\n")
 	fmtc.Printfn("  %s %s /source1.ogg 457", APP, CMD_KILL_CLIENT)
-	fmtc.Printfn("  %s %s source1.ogg 457", APP, CMD_KILL_CLIENT)
+	fmtc.Printfn("  %s %s source1.ogg 457 458 460", APP, CMD_KILL_CLIENT)
+	fmtc.Printfn("  %s %s source1.ogg 100-140,457", APP, CMD_KILL_CLIENT)
+	fmtc.Printfn("  %s %s source1.ogg 192.168.1.15", APP, CMD_KILL_CLIENT)
 	fmtc.NewLine()
 }
 
@@ -680,13 +1263,86 @@ func genUsage() *usage.Info {
 	info.AddCommand(CMD_LIST_CLIENTS, "List clients", "mount")
 	info.AddCommand(CMD_MOVE_CLIENTS, "Move clients between mounts", "from-mount", "to-mount")
 	info.AddCommand(CMD_UPDATE_META, "Update meta for mount", "mount", "artist", "title")
-	info.AddCommand(CMD_KILL_CLIENT, "Kill client connection", "mount", "client-id")
+	info.AddCommand(CMD_KILL_CLIENT, "Kill client connections", "mount", "client-id…")
 	info.AddCommand(CMD_KILL_SOURCE, "Kill source connection", "mount")
+	info.AddCommand(CMD_ABUSERS, "Find duplicate connections and abusers")
+	info.AddCommand(CMD_GEO, "Show listeners geography")
+	info.AddCommand(CMD_PLAYERS, "Show players, apps, OS or devices breakdown", "?mount")
+	info.AddCommand(CMD_REAP, "Kill slow listeners", "?mount")
+	info.AddCommand(CMD_SNAPSHOT, "Save or compare snapshots of listeners", "save|diff", "file", "?mount|file")
+	info.AddCommand(CMD_META_WATCH, "Update meta from now-playing file", "mount", "file")
+	info.AddCommand(CMD_MPD_SYNC, "Update meta from MPD", "mount…")
+	info.AddCommand(CMD_META_HISTORY, "Record or show metadata changes history", "record|show", "file", "?mount")
+	info.AddCommand(CMD_SCHEDULE, "Update meta using programme schedule", "file", "mount…")
+	info.AddCommand(CMD_SERVE_NOWPLAYING, "Start HTTP server with now-playing info")
+	info.AddCommand(CMD_LISTEN_META, "Show in-band metadata of stream", "mount")
+	info.AddCommand(CMD_VERIFY_META, "Compare stats metadata with in-band metadata", "?mount")
+	info.AddCommand(CMD_PUSH, "Stream file or stdin to mount as a source", "mount", "file|-")
+	info.AddCommand(CMD_PLAYLIST_SOURCE, "Stream files from playlist or directory to mount", "mount", "m3u|dir")
+	info.AddCommand(CMD_TEST_SOURCE, "Stream generated test signal to mount", "mount")
+	info.AddCommand(CMD_RECORD_STREAM, "Record stream to files", "mount")
+	info.AddCommand(CMD_LOGGER, "Record mounts to segments with retention", "dir", "mount…")
+	info.AddCommand(CMD_EXTRACT, "Extract audio for time range from logger segments", "dir", "mount", "output")
+	info.AddCommand(CMD_PROBE, "Measure stream and verify codec and bitrate", "mount")
+	info.AddCommand(CMD_DEAD_AIR, "Monitor mounts for dead air and stalled bitrate", "?mount")
 	info.AddCommand(CMD_HELP, "Show detailed info about command usage", "command")
 
 	info.AddOption(OPT_HOST, "URL of Icecast instance {s-}(default: http://127.0.0.1:8000){!}", "host")
 	info.AddOption(OPT_USER, "Admin username {s-}(default: admin){!}", "username")
 	info.AddOption(OPT_PASS, "Admin password {s-}(default: hackme){!}", "password")
+	info.AddOption(OPT_MAX_CONNS, "Max number of connections from one IP and user agent {s-}(default: 5){!}", "num")
+	info.AddOption(OPT_RECONNECT_TIME, "Watching time for reconnect loop detection {s-}(default: 15s){!}", "duration")
+	info.AddOption(OPT_YES, "Answer \"yes\" to all questions")
+	info.AddOption(OPT_GEOIP, "Path to MaxMind GeoIP database", "file")
+	info.AddOption(OPT_UA_INFO, "Show client info parsed from user agent")
+	info.AddOption(OPT_UA_RULES, "Path to file with custom user agent rules", "file")
+	info.AddOption(OPT_GROUP_BY, "Client family type {s-}(player/app/os/device){!}", "type")
+	info.AddOption(OPT_MAX_LAG, "Max client lag {s-}(default: 1MB){!}", "size")
+	info.AddOption(OPT_GRACE, "Grace period for slow clients {s-}(default: 30s){!}", "duration")
+	info.AddOption(OPT_INTERVAL, "Check interval {s-}(default: 5s){!}", "duration")
+	info.AddOption(OPT_DRY_RUN, "Don't kill anything, only log actions")
+	info.AddOption(OPT_LOG, "Path to log file", "file")
+	info.AddOption(OPT_STDIN, "Read metadata from standard input")
+	info.AddOption(OPT_FIELDS, "Metadata fields mapping", "mapping")
+	info.AddOption(OPT_MPD, "MPD address {s-}(default: 127.0.0.1:6600){!}", "address")
+	info.AddOption(OPT_CHARSET, "Metadata charset {s-}(latin1/cp1251/koi8-r…){!}", "charset")
+	info.AddOption(OPT_TRANSLIT, "Transliterate symbols not supported by charset")
+	info.AddOption(OPT_SONG, "Raw song value", "song")
+	info.AddOption(OPT_ALBUM, "Album name", "album")
+	info.AddOption(OPT_ARTWORK, "Artwork URL", "url")
+	info.AddOption(OPT_META_RULES, "Path to file with metadata normalization rules", "file")
+	info.AddOption(OPT_FROM, "Start of time range", "date|duration")
+	info.AddOption(OPT_TO, "End of time range", "date|duration")
+	info.AddOption(OPT_FORMAT, "Output format {s-}(table/csv){!}", "format")
+	info.AddOption(OPT_GROUPS, "Path to file with mount groups", "file")
+	info.AddOption(OPT_LISTEN, "Address to listen {s-}(default: 127.0.0.1:8080){!}", "address")
+	info.AddOption(OPT_CORS, "Allowed CORS origin {s-}(default: *){!}", "origin")
+	info.AddOption(OPT_SSE, "Push updates using Server-Sent Events")
+	info.AddOption(OPT_TIMEOUT, "Max time for waiting stream data {s-}(default: 10s){!}", "duration")
+	info.AddOption(OPT_MAX_AGE, "Max metadata age", "duration")
+	info.AddOption(OPT_SOURCE_USER, "Source username {s-}(default: source){!}", "username")
+	info.AddOption(OPT_SOURCE_PASS, "Source password {s-}(default: hackme){!}", "password")
+	info.AddOption(OPT_CONTENT_TYPE, "Stream content type", "type")
+	info.AddOption(OPT_BITRATE, "Stream bitrate in kbit/s", "kbps")
+	info.AddOption(OPT_STREAM_NAME, "Stream name", "name")
+	info.AddOption(OPT_STREAM_DESC, "Stream description", "text")
+	info.AddOption(OPT_STREAM_GENRE, "Stream genre", "genre")
+	info.AddOption(OPT_STREAM_URL, "Stream website URL", "url")
+	info.AddOption(OPT_PUBLIC, "List stream in public directories")
+	info.AddOption(OPT_SHUFFLE, "Shuffle playlist tracks")
+	info.AddOption(OPT_LOOP, "Play playlist in a loop")
+	info.AddOption(OPT_SIGNAL, "Test signal type {s-}(tone/sweep/silence/beep){!}", "type")
+	info.AddOption(OPT_FREQUENCY, "Test signal frequency {s-}(default: 1000){!}", "hz")
+	info.AddOption(OPT_OUTPUT, "Output directory", "dir")
+	info.AddOption(OPT_TEMPLATE, "File name template", "template")
+	info.AddOption(OPT_SPLIT_TIME, "Max file duration", "duration")
+	info.AddOption(OPT_SPLIT_SIZE, "Max file size", "size")
+	info.AddOption(OPT_SPLIT_META, "Start new file on metadata change")
+	info.AddOption(OPT_SEGMENT, "Logger segment duration {s-}(default: 15m){!}", "duration")
+	info.AddOption(OPT_RETENTION, "Logger retention period {s-}(default: 90d){!}", "duration")
+	info.AddOption(OPT_DURATION, "Probe duration {s-}(default: 10s){!}", "duration")
+	info.AddOption(OPT_SILENCE_LEVEL, "Silence threshold in dBFS {s-}(default: -50){!}", "level")
+	info.AddOption(OPT_SILENCE_TIME, "Max duration of silence {s-}(default: 30s){!}", "duration")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 	info.AddOption(OPT_HELP, "Show this help message")
 	info.AddOption(OPT_VER, "Show version")
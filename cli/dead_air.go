@@ -0,0 +1,311 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// deadAirMonitor monitors signal level and incoming bitrate of mount points
+type deadAirMonitor struct {
+	Mount      string
+	Threshold  float64
+	MaxSilence time.Duration
+	Interval   time.Duration
+
+	mounts map[string]*deadAirMount
+}
+
+// deadAirMount contains state of monitored mount point
+type deadAirMount struct {
+	Mount string
+
+	done        chan struct{} // Closed when source is disconnected
+	stalled     bool          // Incoming bitrate is stalled
+	silent      bool          // Dead air was reported
+	silentSince time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// deadAirWindow is duration of audio used for single level measurement
+const deadAirWindow = time.Second
+
+// minIncomingBitrate is min ratio of incoming bitrate to advertised bitrate
+const minIncomingBitrate = 0.5
+
+// bitrateCheckDelay is delay after source connection before incoming bitrate
+// is checked
+const bitrateCheckDelay = 30 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startDeadAirMonitor starts dead air monitoring for given or all mount points
+func startDeadAirMonitor(mount string) {
+	m := &deadAirMonitor{
+		Threshold: float64(options.GetI(OPT_SILENCE_LEVEL)),
+		mounts:    map[string]*deadAirMount{},
+	}
+
+	if mount != "" {
+		m.Mount = formatMount(mount)
+	}
+
+	var err error
+
+	m.MaxSilence, err = timeutil.ParseDuration(options.GetS(OPT_SILENCE_TIME))
+
+	if err != nil || m.MaxSilence < deadAirWindow {
+		printErrorExit("Silence duration must be at least 1 second")
+	}
+
+	m.Interval, err = parseInterval()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	log.Info(
+		"Dead air monitor started (mount: %s, silence level: %g dBFS, silence time: %s, interval: %s)",
+		formatMountFilter(m.Mount), m.Threshold,
+		timeutil.PrettyDuration(m.MaxSilence), timeutil.PrettyDuration(m.Interval),
+	)
+
+	for {
+		m.Check()
+		time.Sleep(m.Interval)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Check starts monitoring of new sources and checks incoming bitrate of all
+// sources
+func (m *deadAirMonitor) Check() {
+	stats, err := client.GetStats()
+
+	if err != nil {
+		log.Error("Can't get stats: %v", err)
+		return
+	}
+
+	for path, source := range stats.Sources {
+		if m.Mount != "" && path != m.Mount {
+			continue
+		}
+
+		mnt := m.mounts[path]
+
+		if mnt == nil {
+			mnt = &deadAirMount{Mount: path, done: make(chan struct{})}
+			m.mounts[path] = mnt
+
+			log.Info("Monitoring %s", path)
+
+			go m.Listen(mnt)
+		}
+
+		m.checkBitrate(mnt, source)
+	}
+
+	for path, mnt := range m.mounts {
+		if stats.Sources[path] == nil {
+			log.Info("Source %s disconnected, monitoring stopped", path)
+			close(mnt.done)
+			delete(m.mounts, path)
+		}
+	}
+}
+
+// Listen connects to the stream and measures signal level until source is
+// disconnected or codec of the stream turns out to be unsupported
+func (m *deadAirMonitor) Listen(mnt *deadAirMount) {
+	for {
+		err := m.watchStream(mnt)
+
+		if errors.Is(err, audio.ErrUnsupportedCodec) {
+			log.Error("Can't measure signal level of %s: %v, only incoming bitrate will be checked", mnt.Mount, err)
+			return
+		}
+
+		select {
+		case <-mnt.done:
+			return
+		default:
+			log.Error("Stream %s disconnected: %v", mnt.Mount, err)
+		}
+
+		select {
+		case <-mnt.done:
+			return
+		case <-time.After(m.Interval):
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// watchStream reads stream and checks signal level
+func (m *deadAirMonitor) watchStream(mnt *deadAirMount) error {
+	conn, err := openStream(mnt.Mount)
+
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	stop := make(chan struct{})
+
+	go func() {
+		pw.CloseWithError(conn.Read(pw, nil))
+	}()
+
+	go func() {
+		select {
+		case <-mnt.done:
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	defer func() {
+		close(stop)
+		conn.Close()
+		pr.Close()
+	}()
+
+	lr, err := audio.NewLevelReader(pr, audio.FormatByContentType(conn.ContentType))
+
+	if err != nil {
+		return fmt.Errorf("%w (%s)", err, conn.ContentType)
+	}
+
+	for {
+		level, err := lr.Read(deadAirWindow)
+
+		if err != nil {
+			return err
+		}
+
+		m.checkLevel(mnt, level)
+	}
+}
+
+// checkLevel checks signal level and reports dead air
+func (m *deadAirMonitor) checkLevel(mnt *deadAirMount, level float64) {
+	if level >= m.Threshold {
+		if mnt.silent {
+			log.Info(
+				"Signal on %s restored after %s of dead air (level: %.1f dBFS)",
+				mnt.Mount, timeutil.PrettyDuration(time.Since(mnt.silentSince)), level,
+			)
+		}
+
+		mnt.silent, mnt.silentSince = false, time.Time{}
+
+		return
+	}
+
+	if mnt.silentSince.IsZero() {
+		mnt.silentSince = time.Now()
+	}
+
+	if !mnt.silent && time.Since(mnt.silentSince) >= m.MaxSilence {
+		mnt.silent = true
+
+		log.Warn(
+			"Dead air on %s: signal level is below %g dBFS for %s (level: %.1f dBFS)",
+			mnt.Mount, m.Threshold, timeutil.PrettyDuration(time.Since(mnt.silentSince)), level,
+		)
+	}
+}
+
+// checkBitrate checks incoming bitrate of the source and reports stalled
+// bitrate
+func (m *deadAirMonitor) checkBitrate(mnt *deadAirMount, source *ic.Source) {
+	if source.Stats == nil || time.Duration(source.Stats.Connected)*time.Second < bitrateCheckDelay {
+		return
+	}
+
+	incoming := source.Stats.IncomingBitrate / 1000
+	advertised := 0
+
+	if source.AudioInfo != nil {
+		advertised = source.AudioInfo.Bitrate
+	}
+
+	stalled := incoming == 0 ||
+		(advertised > 0 && float64(incoming) < float64(advertised)*minIncomingBitrate)
+
+	switch {
+	case stalled && !mnt.stalled:
+		log.Warn(
+			"Incoming bitrate of %s is stalled: %d kbit/s (advertised: %d kbit/s)",
+			mnt.Mount, incoming, advertised,
+		)
+	case !stalled && mnt.stalled:
+		log.Info("Incoming bitrate of %s restored: %d kbit/s", mnt.Mount, incoming)
+	}
+
+	mnt.stalled = stalled
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdDeadAir shows help for "dead-air" command
+func helpCmdDeadAir() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Connects to all (or given) mountpoints as a listener, measures signal level")
+	fmtc.Println("  and reports dead air if level stays below the threshold for longer than")
+	fmtc.Println("  silence time. Stalled incoming bitrate of sources is reported too. Every")
+	fmtc.Println("  alert and recovery is written to the log.")
+	fmtc.NewLine()
+	fmtc.Println("  Streams are decoded and their RMS level is measured, so constant hiss or hum")
+	fmtc.Println("  is reported as dead air too if it's quieter than the threshold. Supported")
+	fmtc.Println("  codecs are MP3, Vorbis, Opus and FLAC. If stream uses other codec {s-}(AAC, MP2,{!}")
+	fmtc.Println("  {s-}multichannel Opus…){!}, error is written to the log and only incoming bitrate")
+	fmtc.Println("  is checked for this mountpoint.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_DEAD_AIR)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(optional, with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-15s{!} - Silence threshold in dBFS {s-}(default: -50){!}", options.F(OPT_SILENCE_LEVEL))
+	fmtc.Printfn("  {g}%-15s{!} - Max duration of silence {s-}(default: 30s){!}", options.F(OPT_SILENCE_TIME))
+	fmtc.Printfn("  {g}%-15s{!} - Stats check interval {s-}(default: 5s){!}", options.F(OPT_INTERVAL))
+	fmtc.Printfn("  {g}%-15s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s", APP, CMD_DEAD_AIR)
+	fmtc.Printfn("  %s %s %s -60 %s 1m /source1.mp3", APP, CMD_DEAD_AIR, options.F(OPT_SILENCE_LEVEL), options.F(OPT_SILENCE_TIME))
+	fmtc.NewLine()
+}
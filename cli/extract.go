@@ -0,0 +1,375 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+	"github.com/essentialkaos/icecli/cli/record"
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// segment is segment file created by logger
+type segment struct {
+	File   string
+	Start  time.Time
+	Format string
+}
+
+// extractor writes audio chunks from given time range
+type extractor struct {
+	w    io.Writer
+	from time.Time
+	to   time.Time
+
+	size     int64
+	duration time.Duration
+
+	headers   [][]byte // Header pages of current Ogg logical stream
+	inHeaders bool     // Header pages are being collected
+	written   []byte   // Last written header pages
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// maxSegmentGap is max difference between end of segment and start of the next
+// segment which is not reported as a gap in recording
+const maxSegmentGap = 5 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// errRangeEnd is returned when the end of time range is reached
+var errRangeEnd = errors.New("End of time range")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// extract stitches audio for given time range from logger segments
+func extract(dir, mount, output string) {
+	if !options.Has(OPT_FROM) || !options.Has(OPT_TO) {
+		printErrorExit("Options %s and %s are required", options.F(OPT_FROM), options.F(OPT_TO))
+	}
+
+	from, err := parseHistoryTime(options.GetS(OPT_FROM))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_FROM), err)
+	}
+
+	to, err := parseHistoryTime(options.GetS(OPT_TO))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_TO), err)
+	}
+
+	if !to.After(from) {
+		printErrorExit("End of time range must be after its start")
+	}
+
+	mount = formatMount(mount)
+	segments, err := findSegments(filepath.Join(dir, record.MountName(mount)), from, to)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	if len(segments) == 0 {
+		printErrorExit("No segments of %s found for given time range", mount)
+	}
+
+	format := segments[0].Format
+
+	if output != "-" && audio.FormatByExt(output) != "" && audio.FormatByExt(output) != format {
+		printErrorExit("Output file extension doesn't match format of segments (%s)", format)
+	}
+
+	fd, err := openExtractOutput(output)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	w := bufio.NewWriter(fd)
+	e := &extractor{w: w, from: from, to: to}
+
+	err = e.Extract(segments, format)
+
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err == nil {
+		err = fd.Close()
+	}
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	if output == "-" {
+		return
+	}
+
+	fmtc.Printfn(
+		"{g}Extracted %s of audio from %d segments to %s {s}(%s){!}",
+		timeutil.PrettyDuration(e.duration), len(segments),
+		output, fmtutil.PrettySize(e.size),
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Extract writes audio from given segments
+func (e *extractor) Extract(segments []*segment, format string) error {
+	var end time.Time
+
+	for _, s := range segments {
+		if s.Format != format {
+			terminal.Warn("Segment %s skipped: format doesn't match %s", s.File, format)
+			continue
+		}
+
+		if !end.IsZero() && s.Start.Sub(end) > maxSegmentGap {
+			terminal.Warn(
+				"Gap in recording: %s (%s – %s)",
+				timeutil.PrettyDuration(s.Start.Sub(end)),
+				timeutil.Format(end, "%Y/%m/%d %H:%M:%S"),
+				timeutil.Format(s.Start, "%Y/%m/%d %H:%M:%S"),
+			)
+		}
+
+		dur, err := e.extractSegment(s)
+
+		if err == errRangeEnd {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		end = s.Start.Add(dur)
+	}
+
+	return nil
+}
+
+// extractSegment writes chunks of segment which belong to time range and
+// returns duration of segment
+func (e *extractor) extractSegment(s *segment) (time.Duration, error) {
+	fd, err := os.Open(s.File)
+
+	if err != nil {
+		return 0, fmt.Errorf("Can't open segment: %w", err)
+	}
+
+	defer fd.Close()
+
+	r, err := audio.NewReader(fd, s.Format)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var dur time.Duration
+
+	for {
+		chunk, err := r.Read()
+
+		if err == io.EOF {
+			return dur, nil
+		}
+
+		if err != nil {
+			return dur, fmt.Errorf("Can't read segment %s: %w", s.File, err)
+		}
+
+		pos := s.Start.Add(dur)
+		dur += chunk.Duration
+
+		if !pos.Before(e.to) {
+			return dur, errRangeEnd
+		}
+
+		err = e.writeChunk(chunk, s.Format, pos)
+
+		if err != nil {
+			return dur, err
+		}
+	}
+}
+
+// writeChunk writes chunk if it belongs to time range. Header pages of Ogg
+// stream are written before the first page of logical stream.
+func (e *extractor) writeChunk(chunk *audio.Chunk, format string, pos time.Time) error {
+	inRange := !pos.Add(chunk.Duration).Before(e.from)
+
+	if format == audio.FORMAT_OGG {
+		flags := chunk.Data[5]
+		granule := binary.LittleEndian.Uint64(chunk.Data[6:14])
+
+		switch {
+		case flags&stream.OGG_FLAG_BOS != 0:
+			if !e.inHeaders {
+				e.headers, e.inHeaders = nil, true
+			}
+
+			e.headers = append(e.headers, chunk.Data)
+
+			return nil
+
+		case e.inHeaders && (granule == 0 || granule == ^uint64(0)):
+			e.headers = append(e.headers, chunk.Data)
+			return nil
+		}
+
+		e.inHeaders = false
+
+		// Segments of the same logical stream contain the same header pages, so
+		// they are written only once
+		headers := bytes.Join(e.headers, nil)
+
+		if inRange && !bytes.Equal(headers, e.written) {
+			err := e.write(headers)
+
+			if err != nil {
+				return err
+			}
+
+			e.written = headers
+		}
+	}
+
+	if !inRange {
+		return nil
+	}
+
+	e.duration += chunk.Duration
+
+	return e.write(chunk.Data)
+}
+
+// write writes data to output
+func (e *extractor) write(data []byte) error {
+	n, err := e.w.Write(data)
+	e.size += int64(n)
+
+	if err != nil {
+		return fmt.Errorf("Can't write data: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findSegments returns sorted list of segments which may contain audio from
+// given time range
+func findSegments(dir string, from, to time.Time) ([]*segment, error) {
+	var segments []*segment
+
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := d.Name()
+		format := audio.FormatByExt(name)
+
+		if d.IsDir() || format == "" || len(name) < len(loggerTimeLayout) {
+			return nil
+		}
+
+		start, err := time.ParseInLocation(loggerTimeLayout, name[:len(loggerTimeLayout)], time.Local)
+
+		if err != nil || !start.Before(to) {
+			return nil
+		}
+
+		segments = append(segments, &segment{File: file, Start: start, Format: format})
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read segments: %w", err)
+	}
+
+	slices.SortFunc(segments, func(a, b *segment) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	// Segment ends before the start of time range if the next segment was
+	// started before it
+	for len(segments) > 1 && !segments[1].Start.After(from) {
+		segments = segments[1:]
+	}
+
+	return segments, nil
+}
+
+// openExtractOutput opens output file or stdout
+func openExtractOutput(output string) (*os.File, error) {
+	if output == "-" {
+		return os.Stdout, nil
+	}
+
+	fd, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't create output file: %w", err)
+	}
+
+	return fd, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdExtract shows help for "extract" command
+func helpCmdExtract() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Printfn("  Stitches audio for given time range from segments recorded by {y}%s{!}", CMD_LOGGER)
+	fmtc.Println("  command. Audio is cut on MP3/AAC frame or Ogg page boundary, gaps in recording")
+	fmtc.Println("  are reported.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}dir{!} {g}mount{!} {g}output{!}", APP, CMD_EXTRACT)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}dir{!}    - Logger output directory")
+	fmtc.Println("  {g}mount{!}  - Mount name {s-}(with or without leading slash){!}")
+	fmtc.Println("  {g}output{!} - Output file or {y}-{!} for stdout")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-6s{!} - Start of time range {s-}(date or duration ago, required){!}", options.F(OPT_FROM))
+	fmtc.Printfn("  {g}%-6s{!} - End of time range {s-}(date or duration ago, required){!}", options.F(OPT_TO))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s %s '2025-06-01 18:00' %s '2025-06-01 19:30' /srv/aircheck /source1.mp3 show.mp3", APP, CMD_EXTRACT, options.F(OPT_FROM), options.F(OPT_TO))
+	fmtc.Printfn("  %s %s %s 2h %s 1h /srv/aircheck /source1.ogg - | mpv -", APP, CMD_EXTRACT, options.F(OPT_FROM), options.F(OPT_TO))
+	fmtc.NewLine()
+}
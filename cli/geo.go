@@ -0,0 +1,188 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/mathutil"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// geoRecord contains location info from MaxMind database
+type geoRecord struct {
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+}
+
+// geoStat contains number of listeners from some country on some mount point
+type geoStat struct {
+	Mount     string
+	Country   string
+	Listeners int
+	Total     int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// geoDB is GeoIP database reader
+var geoDB *maxminddb.Reader
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// showGeoStats prints number of listeners per country per mount point
+func showGeoStats() {
+	if !options.Has(OPT_GEOIP) {
+		printErrorExit("This command requires GeoIP database %s", options.F(OPT_GEOIP))
+	}
+
+	err := openGeoDB()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	clients, err := listAllClients()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	if len(clients) == 0 {
+		fmtc.Println("{y}No listeners found{!}")
+		return
+	}
+
+	t := table.NewTable("mount", "country", "listeners", "share")
+	t.SetAlignments(table.ALIGN_LEFT, table.ALIGN_LEFT, table.ALIGN_RIGHT, table.ALIGN_RIGHT)
+	t.SetSizes(20, 24, 10, 8)
+
+	fmtc.NewLine()
+
+	for _, s := range aggregateGeoStats(clients) {
+		t.Print(
+			s.Mount, s.Country, fmtutil.PrettyNum(s.Listeners),
+			fmtutil.PrettyPerc(mathutil.Perc(s.Listeners, s.Total)),
+		)
+	}
+
+	t.Separator()
+
+	fmtc.NewLine()
+}
+
+// aggregateGeoStats groups clients by mount point and country
+func aggregateGeoStats(clients []*mountClient) []*geoStat {
+	index := map[string]*geoStat{}
+	totals := map[string]int{}
+
+	for _, c := range clients {
+		country, _ := lookupGeo(c.IP)
+		key := c.Mount + "|" + country
+
+		if index[key] == nil {
+			index[key] = &geoStat{Mount: c.Mount, Country: country}
+		}
+
+		index[key].Listeners++
+		totals[c.Mount]++
+	}
+
+	var result []*geoStat
+
+	for _, s := range index {
+		s.Total = totals[s.Mount]
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Mount != result[j].Mount {
+			return result[i].Mount < result[j].Mount
+		}
+
+		if result[i].Listeners != result[j].Listeners {
+			return result[i].Listeners > result[j].Listeners
+		}
+
+		return result[i].Country < result[j].Country
+	})
+
+	return result
+}
+
+// openGeoDB opens GeoIP database
+func openGeoDB() error {
+	var err error
+
+	geoDB, err = maxminddb.Open(options.GetS(OPT_GEOIP))
+
+	if err != nil {
+		return fmt.Errorf("Can't open GeoIP database: %w", err)
+	}
+
+	return nil
+}
+
+// lookupGeo returns country and city for given IP
+func lookupGeo(ip string) (string, string) {
+	addr := net.ParseIP(ip)
+
+	if geoDB == nil || addr == nil {
+		return "Unknown", ""
+	}
+
+	rec := &geoRecord{}
+	err := geoDB.Lookup(addr, rec)
+
+	if err != nil || rec.Country.ISOCode == "" {
+		return "Unknown", ""
+	}
+
+	country := rec.Country.ISOCode
+
+	if rec.Country.Names["en"] != "" {
+		country += " " + rec.Country.Names["en"]
+	}
+
+	return country, rec.City.Names["en"]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdGeo shows help for "geo" command
+func helpCmdGeo() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Shows number of listeners per country for every mountpoint. Location of")
+	fmtc.Println("  listeners is resolved using local MaxMind database (GeoLite2 or GeoIP2).")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!}", APP, CMD_GEO)
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%s{!} - Path to MaxMind database {s-}(required){!}", options.F(OPT_GEOIP))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s %s /usr/share/GeoIP/GeoLite2-City.mmdb", APP, CMD_GEO, options.F(OPT_GEOIP))
+	fmtc.NewLine()
+}
@@ -0,0 +1,103 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// streamTimeout is connection timeout for listener connections
+var streamTimeout = 10 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// listenMeta connects to mount point as a listener and prints every in-band
+// metadata change
+func listenMeta(mount string) {
+	mount = formatMount(mount)
+	conn, err := openStream(mount)
+
+	if err != nil {
+		printErrorExit("Can't connect to %s: %v", mount, err)
+	}
+
+	defer conn.Close()
+
+	switch conn.Format {
+	case stream.FORMAT_ICY:
+		fmtc.Printfn(
+			"{s-}Listening %s {s}(%s, ICY metadata every %d bytes){!}{s-}…{!}",
+			mount, formatString(conn.ContentType), conn.MetaInt,
+		)
+	case stream.FORMAT_OGG:
+		fmtc.Printfn(
+			"{s-}Listening %s {s}(%s, Ogg comments){!}{s-}…{!}",
+			mount, formatString(conn.ContentType),
+		)
+	default:
+		printErrorExit("Stream %s (%s) doesn't contain in-band metadata", mount, formatString(conn.ContentType))
+	}
+
+	var lastMeta string
+
+	err = conn.Read(nil, func(meta *stream.Meta) {
+		if meta.String() == lastMeta {
+			return
+		}
+
+		lastMeta = meta.String()
+
+		fmtc.Printfn(
+			"{s-}%s{!} %s",
+			timeutil.Format(time.Now(), "%Y/%m/%d %H:%M:%S"),
+			formatString(meta.String()),
+		)
+	})
+
+	if err != nil {
+		printErrorExit("Stream reading error: %v", err)
+	}
+}
+
+// openStream connects to mount point as a listener
+func openStream(mount string) (*stream.Conn, error) {
+	return stream.Open(getMountURL(mount), streamTimeout)
+}
+
+// getMountURL returns URL of mount point
+func getMountURL(mount string) string {
+	return getHostURL() + mount
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdListenMeta shows help for "listen-meta" command
+func helpCmdListenMeta() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Connects to the mountpoint as a listener and prints every change of metadata")
+	fmtc.Println("  embedded into the stream (ICY metadata blocks for MP3/AAC streams or comment")
+	fmtc.Println("  headers for Ogg streams). Audio data is not stored.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_LISTEN_META)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /source1.mp3", APP, CMD_LISTEN_META)
+	fmtc.NewLine()
+}
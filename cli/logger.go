@@ -0,0 +1,204 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/icecli/cli/record"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// loggerTemplate is template for names of logger segments
+const loggerTemplate = "{mount}/%Y/%m/%d/%Y%m%d-%H%M%S"
+
+// loggerTimeLayout is layout of segment start time in segment file name
+const loggerTimeLayout = "20060102-150405"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// retentionCheckInterval is interval between checks for expired segments
+var retentionCheckInterval = time.Hour
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startLogger starts continuous recording of given mount points to segments
+func startLogger(dir string, mounts []string) {
+	segment, err := timeutil.ParseDuration(options.GetS(OPT_SEGMENT))
+
+	if err != nil || segment < time.Minute {
+		printErrorExit("Segment duration must be at least 1 minute")
+	}
+
+	retention, err := timeutil.ParseDuration(options.GetS(OPT_RETENTION))
+
+	if err != nil || retention < segment {
+		printErrorExit("Retention period must be longer than segment duration")
+	}
+
+	err = os.MkdirAll(dir, 0755)
+
+	if err != nil {
+		printErrorExit("Can't create output directory: %v", err)
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	errs := make(chan error, len(mounts))
+
+	for i, m := range mounts {
+		mounts[i] = formatMount(m)
+
+		config := &record.Config{
+			Dir:       dir,
+			Template:  loggerTemplate,
+			Mount:     mounts[i],
+			SplitTime: segment,
+			Align:     true,
+		}
+
+		go func(mount string) {
+			errs <- fmt.Errorf("Recording of %s stopped: %w", mount, recordMount(mount, config))
+		}(mounts[i])
+	}
+
+	log.Info(
+		"Logging %s to %s (segment: %s, retention: %s)",
+		strings.Join(mounts, ", "), dir,
+		timeutil.PrettyDuration(segment), timeutil.PrettyDuration(retention),
+	)
+
+	go cleanSegmentsLoop(dir, mounts, retention)
+
+	err = <-errs
+
+	log.Crit(err.Error())
+	printErrorExit(err.Error())
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cleanSegmentsLoop periodically removes expired segments
+func cleanSegmentsLoop(dir string, mounts []string, retention time.Duration) {
+	for {
+		for _, mount := range mounts {
+			removed, err := cleanSegments(
+				filepath.Join(dir, record.MountName(mount)),
+				time.Now().Add(-retention),
+			)
+
+			if err != nil {
+				log.Error("Can't remove expired segments of %s: %v", mount, err)
+			}
+
+			if removed > 0 {
+				log.Info("Removed %d expired segments of %s", removed, mount)
+			}
+		}
+
+		time.Sleep(retentionCheckInterval)
+	}
+}
+
+// cleanSegments removes segments modified before given date and empty
+// directories
+func cleanSegments(dir string, cutoff time.Time) (int, error) {
+	var dirs []string
+	var removed int
+
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		case d.IsDir():
+			if file != dir {
+				dirs = append(dirs, file)
+			}
+
+			return nil
+		}
+
+		info, err := d.Info()
+
+		if err != nil || !info.ModTime().Before(cutoff) {
+			return nil
+		}
+
+		err = os.Remove(file)
+
+		if err != nil {
+			return err
+		}
+
+		removed++
+
+		return nil
+	})
+
+	// Remove nested directories first, non-empty directories are kept
+	slices.Reverse(dirs)
+
+	for _, d := range dirs {
+		os.Remove(d)
+	}
+
+	return removed, err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdLogger shows help for "logger" command
+func helpCmdLogger() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Continuously records streams of given mountpoints into fixed-length segments")
+	fmtc.Println("  (aircheck logs). Segment boundaries are aligned to multiples of segment")
+	fmtc.Println("  duration, so with default settings segments start at :00, :15, :30 and :45.")
+	fmtc.Println("  Segments older than retention period are removed automatically.")
+	fmtc.NewLine()
+	fmtc.Println("  Segments are saved as {s-}dir/mount/YYYY/MM/DD/YYYYMMDD-HHMMSS.ext{!}. Use")
+	fmtc.Printfn("  {y}%s{!} command to get audio for an arbitrary time range.", CMD_EXTRACT)
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}dir{!} {g}mount…{!}", APP, CMD_LOGGER)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}dir{!}   - Output directory")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-11s{!} - Segment duration {s-}(default: 15m){!}", options.F(OPT_SEGMENT))
+	fmtc.Printfn("  {g}%-11s{!} - Retention period {s-}(default: 90d){!}", options.F(OPT_RETENTION))
+	fmtc.Printfn("  {g}%-11s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /srv/aircheck /source1.mp3 /source2.ogg", APP, CMD_LOGGER)
+	fmtc.Printfn("  %s %s %s 1h %s 30d %s /var/log/icecli.log /srv/aircheck /source1.mp3", APP, CMD_LOGGER, options.F(OPT_SEGMENT), options.F(OPT_RETENTION), options.F(OPT_LOG))
+	fmtc.NewLine()
+}
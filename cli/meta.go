@@ -0,0 +1,303 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/sliceutil"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+
+	"github.com/essentialkaos/icecli/cli/charset"
+	"github.com/essentialkaos/icecli/cli/metarules"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MAX_META_SIZE is max size of stream title in ICY metadata block (255 × 16
+// bytes minus StreamTitle field wrapper)
+const MAX_META_SIZE = 4065
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// trackInfo contains track metadata from external source
+type trackInfo struct {
+	Song    string `json:"song"`
+	Artist  string `json:"artist"`
+	Title   string `json:"title"`
+	Album   string `json:"album"`
+	Artwork string `json:"artwork"`
+}
+
+// metaResult contains result of metadata update for mount point
+type metaResult struct {
+	Mount string
+	Err   error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// metaRules is metadata normalization rules
+var metaRules *metarules.Rules
+
+// metaFields is list of supported metadata fields
+var metaFields = []string{"song", "artist", "title", "album", "artwork"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readMetaFromStdin reads tracks metadata from standard input and updates
+// metadata for given mount points on every track change
+func readMetaFromStdin(target string) {
+	mounts, err := resolveMounts(target)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = readMetaFeed(os.Stdin, mounts)
+
+	if err != nil {
+		printErrorExit("Can't read metadata from stdin: %v", err)
+	}
+}
+
+// readMetaFeed reads tracks metadata line by line from given reader
+func readMetaFeed(r io.Reader, mounts []string) error {
+	var lastTrack trackInfo
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		track, err := parseMetaLine(line)
+
+		if err != nil {
+			log.Error("Can't parse metadata line %q: %v", line, err)
+			continue
+		}
+
+		if *track == lastTrack {
+			continue
+		}
+
+		meta := track.ToMeta()
+		hasErrors := false
+
+		for _, res := range sendMetaToMounts(mounts, meta) {
+			if res.Err != nil {
+				log.Error("Can't update metadata for %s: %v", res.Mount, res.Err)
+				hasErrors = true
+				continue
+			}
+
+			log.Info("Metadata updated for %s: %s", res.Mount, formatTrackMeta(meta))
+		}
+
+		// Keep previous track on error, so update will be retried if the same
+		// track will be received again
+		if !hasErrors {
+			lastTrack = *track
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseMetaLine parses line with track metadata in "artist - title" or JSON
+// format
+func parseMetaLine(line string) (*trackInfo, error) {
+	track := &trackInfo{}
+
+	if strings.HasPrefix(line, "{") {
+		err := json.Unmarshal([]byte(line), track)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		artist, title, ok := strings.Cut(line, " - ")
+
+		if ok {
+			track.Artist, track.Title = strings.TrimSpace(artist), strings.TrimSpace(title)
+		} else {
+			track.Title = line
+		}
+	}
+
+	if track.IsEmpty() {
+		return nil, fmt.Errorf("Metadata is empty")
+	}
+
+	return track, nil
+}
+
+// initMetaRules loads metadata normalization rules
+func initMetaRules() error {
+	if !options.Has(OPT_META_RULES) {
+		return nil
+	}
+
+	var err error
+
+	metaRules, err = metarules.LoadFile(options.GetS(OPT_META_RULES))
+
+	if err != nil {
+		return fmt.Errorf("Can't load metadata rules: %w", err)
+	}
+
+	return nil
+}
+
+// sendMetaToMounts concurrently sends track metadata to given mount points
+func sendMetaToMounts(mounts []string, meta ic.TrackMeta) []*metaResult {
+	var wg sync.WaitGroup
+
+	results := make([]*metaResult, len(mounts))
+
+	for i, mount := range mounts {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			results[i] = &metaResult{mount, sendMeta(mount, meta)}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// sendMeta sends track metadata to the mount point
+func sendMeta(mount string, meta ic.TrackMeta) error {
+	meta, err := encodeMeta(metaRules.Apply(meta))
+
+	if err != nil {
+		return err
+	}
+
+	return client.UpdateMeta(mount, meta)
+}
+
+// encodeMeta converts metadata to charset defined by options and truncates it
+// to ICY metadata size limit
+func encodeMeta(meta ic.TrackMeta) (ic.TrackMeta, error) {
+	cs := charset.UTF8
+
+	if options.Has(OPT_CHARSET) {
+		var err error
+
+		cs, err = charset.Get(options.GetS(OPT_CHARSET))
+
+		if err != nil {
+			return meta, err
+		}
+
+		translit := options.GetB(OPT_TRANSLIT)
+
+		meta.Song = cs.Encode(meta.Song, translit)
+		meta.Artist = cs.Encode(meta.Artist, translit)
+		meta.Title = cs.Encode(meta.Title, translit)
+		meta.Album = cs.Encode(meta.Album, translit)
+		meta.Charset = cs.Name
+	}
+
+	return truncateMeta(meta, cs), nil
+}
+
+// truncateMeta truncates metadata to ICY metadata size limit. Title is
+// truncated first, because artist is more important for listeners.
+func truncateMeta(meta ic.TrackMeta, cs *charset.Charset) ic.TrackMeta {
+	if meta.Song != "" {
+		meta.Song = cs.Truncate(meta.Song, MAX_META_SIZE)
+		return meta
+	}
+
+	if meta.Artist == "" {
+		meta.Title = cs.Truncate(meta.Title, MAX_META_SIZE)
+		return meta
+	}
+
+	// Icecast joins artist and title using " - " separator
+	meta.Artist = cs.Truncate(meta.Artist, MAX_META_SIZE-3)
+	meta.Title = cs.Truncate(meta.Title, MAX_META_SIZE-3-len(meta.Artist))
+
+	return meta
+}
+
+// isSupportedMetaField returns true if metadata field with given name is supported
+func isSupportedMetaField(name string) bool {
+	return sliceutil.Contains(metaFields, name)
+}
+
+// formatTrackMeta formats track metadata for logging
+func formatTrackMeta(meta ic.TrackMeta) string {
+	if meta.Song != "" {
+		return meta.Song
+	}
+
+	if meta.Artist == "" {
+		return meta.Title
+	}
+
+	return meta.Artist + " - " + meta.Title
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Set sets value of metadata field with given name
+func (t *trackInfo) Set(name, value string) {
+	switch name {
+	case "song":
+		t.Song = value
+	case "artist":
+		t.Artist = value
+	case "title":
+		t.Title = value
+	case "album":
+		t.Album = value
+	case "artwork":
+		t.Artwork = value
+	}
+}
+
+// IsEmpty returns true if track info doesn't contain song, artist and title
+func (t *trackInfo) IsEmpty() bool {
+	return t.Song == "" && t.Artist == "" && t.Title == ""
+}
+
+// ToMeta converts track info to Icecast track metadata
+func (t *trackInfo) ToMeta() ic.TrackMeta {
+	return ic.TrackMeta{
+		Song:    t.Song,
+		Artist:  t.Artist,
+		Title:   t.Title,
+		Album:   t.Album,
+		Artwork: t.Artwork,
+	}
+}
@@ -0,0 +1,407 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	HISTORY_RECORD = "record"
+	HISTORY_SHOW   = "show"
+)
+
+const (
+	FORMAT_TABLE = "table"
+	FORMAT_CSV   = "csv"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// historyRecord is record about metadata change
+type historyRecord struct {
+	Date    time.Time `json:"date"`
+	Mount   string    `json:"mount"`
+	Artist  string    `json:"artist"`
+	Title   string    `json:"title"`
+	RawInfo string    `json:"raw_info"`
+	Updated time.Time `json:"updated"`
+}
+
+// historyRecorder polls server stats and records metadata changes to log
+type historyRecorder struct {
+	File     string
+	Interval time.Duration
+
+	fd         *os.File
+	lastChange map[string]*historyRecord
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// historyTimeLayouts is list of supported layouts for time range
+var historyTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// processMetaHistoryCommand processes meta-history subcommands
+func processMetaHistoryCommand(args options.Arguments) {
+	checkForRequiredArgs(args, 2)
+
+	switch args.Get(1).ToLower().String() {
+	case HISTORY_RECORD:
+		recordMetaHistory(args.Get(2).String())
+	case HISTORY_SHOW:
+		showMetaHistory(args.Get(2).String(), args.Get(3).String())
+	default:
+		printErrorExit("Unknown meta-history action %q", args.Get(1).String())
+	}
+}
+
+// recordMetaHistory starts recording metadata changes to the log
+func recordMetaHistory(file string) {
+	r := &historyRecorder{File: file}
+
+	var err error
+
+	r.Interval, err = parseInterval()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = r.Open()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	defer r.Close()
+
+	log.Info(
+		"Metadata history recorder started (log: %s, interval: %s)",
+		r.File, timeutil.PrettyDuration(r.Interval),
+	)
+
+	for {
+		r.Check()
+		time.Sleep(r.Interval)
+	}
+}
+
+// showMetaHistory prints metadata changes from the log
+func showMetaHistory(file, mount string) {
+	if mount != "" {
+		mount = formatMount(mount)
+	}
+
+	from, err := parseHistoryTime(options.GetS(OPT_FROM))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_FROM), err)
+	}
+
+	to, err := parseHistoryTime(options.GetS(OPT_TO))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_TO), err)
+	}
+
+	records, err := readMetaHistory(file, func(r *historyRecord) bool {
+		switch {
+		case mount != "" && r.Mount != mount,
+			!from.IsZero() && r.Date.Before(from),
+			!to.IsZero() && r.Date.After(to):
+			return false
+		}
+
+		return true
+	})
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	switch options.GetS(OPT_FORMAT) {
+	case FORMAT_CSV:
+		err = printMetaHistoryCSV(records)
+	case FORMAT_TABLE, "":
+		printMetaHistoryTable(records)
+	default:
+		printErrorExit("Unsupported %s value %q", options.F(OPT_FORMAT), options.GetS(OPT_FORMAT))
+	}
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Open opens log file and reads last known state of mount points from it
+func (r *historyRecorder) Open() error {
+	r.lastChange = map[string]*historyRecord{}
+
+	_, err := readMetaHistory(r.File, func(rec *historyRecord) bool {
+		r.lastChange[rec.Mount] = rec
+		return false
+	})
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	r.fd, err = os.OpenFile(r.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't open history log: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes log file
+func (r *historyRecorder) Close() error {
+	return r.fd.Close()
+}
+
+// Check fetches current metadata for all mount points and records changes
+func (r *historyRecorder) Check() {
+	stats, err := client.GetStats()
+
+	if err != nil {
+		log.Error("Can't get server stats: %v", err)
+		return
+	}
+
+	now := time.Now()
+
+	for mount, source := range stats.Sources {
+		if source.Track == nil {
+			continue
+		}
+
+		rec := newHistoryRecord(now, mount, source)
+		last := r.lastChange[mount]
+
+		if last != nil && last.IsSameTrack(rec) {
+			continue
+		}
+
+		err = r.Write(rec)
+
+		if err != nil {
+			log.Error("Can't write record to history log: %v", err)
+			continue
+		}
+
+		r.lastChange[mount] = rec
+
+		log.Info("Metadata changed for %s: %s", mount, formatTrackMeta(ic.TrackMeta{
+			Artist: rec.Artist,
+			Title:  rec.Title,
+		}))
+	}
+}
+
+// Write appends record to log file
+func (r *historyRecorder) Write(rec *historyRecord) error {
+	data, err := json.Marshal(rec)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = r.fd.Write(append(data, '\n'))
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsSameTrack returns true if records contain the same track
+func (r *historyRecord) IsSameTrack(rec *historyRecord) bool {
+	return r.Artist == rec.Artist &&
+		r.Title == rec.Title &&
+		r.RawInfo == rec.RawInfo &&
+		r.Updated.Equal(rec.Updated)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newHistoryRecord creates new history record from source info
+func newHistoryRecord(date time.Time, mount string, source *ic.Source) *historyRecord {
+	return &historyRecord{
+		Date:    date,
+		Mount:   mount,
+		Artist:  source.Track.Artist,
+		Title:   source.Track.Title,
+		RawInfo: source.Track.RawInfo,
+		Updated: source.MetadataUpdated,
+	}
+}
+
+// readMetaHistory reads records from log file which match given filter
+func readMetaHistory(file string, filter func(r *historyRecord) bool) ([]*historyRecord, error) {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't open history log: %w", err)
+	}
+
+	defer fd.Close()
+
+	var result []*historyRecord
+
+	scanner := bufio.NewScanner(fd)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		rec := &historyRecord{}
+		err = json.Unmarshal(scanner.Bytes(), rec)
+
+		// Skip broken records (e.g. partially written on crash)
+		if err != nil {
+			continue
+		}
+
+		if filter(rec) {
+			result = append(result, rec)
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// parseHistoryTime parses date or duration relative to current time
+func parseHistoryTime(data string) (time.Time, error) {
+	if data == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range historyTimeLayouts {
+		t, err := time.ParseInLocation(layout, data, time.Local)
+
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	d, err := timeutil.ParseDuration(data)
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date or duration", data)
+	}
+
+	return time.Now().Add(-d), nil
+}
+
+// printMetaHistoryTable prints metadata history as a table
+func printMetaHistoryTable(records []*historyRecord) {
+	if len(records) == 0 {
+		fmtc.Println("{y}No metadata changes found{!}")
+		return
+	}
+
+	t := table.NewTable("date", "mount", "artist", "title")
+	t.SetSizes(19, 20, 30)
+
+	fmtc.NewLine()
+
+	for _, r := range records {
+		t.Print(
+			timeutil.Format(r.Date, "%Y/%m/%d %H:%M:%S"),
+			r.Mount, formatString(r.Artist), formatString(r.Title),
+		)
+	}
+
+	t.Separator()
+
+	fmtc.Printfn(" {s-}Records: %s{!}", fmtutil.PrettyNum(len(records)))
+	fmtc.NewLine()
+}
+
+// printMetaHistoryCSV prints metadata history in CSV format
+func printMetaHistoryCSV(records []*historyRecord) error {
+	w := csv.NewWriter(os.Stdout)
+
+	w.Write([]string{"date", "mount", "artist", "title", "raw_info", "updated"})
+
+	for _, r := range records {
+		w.Write([]string{
+			r.Date.Format(time.RFC3339), r.Mount, r.Artist, r.Title,
+			r.RawInfo, r.Updated.Format(time.RFC3339),
+		})
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdMetaHistory shows help for "meta-history" command
+func helpCmdMetaHistory() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Records every metadata change of all mountpoints to the append-only log and")
+	fmtc.Println("  shows changes from the log for given mountpoint and time range. Every record")
+	fmtc.Println("  contains artist, title, raw info and time of metadata update reported by")
+	fmtc.Println("  Icecast.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s %s{!} {g}file{!}", APP, CMD_META_HISTORY, HISTORY_RECORD)
+	fmtc.Printfn("  {c*}%s{!} {y}%s %s{!} {g}file mount{!}", APP, CMD_META_HISTORY, HISTORY_SHOW)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}file {!} - Path to history log")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(optional, with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-14s{!} - Stats polling interval {s-}(default: 5s){!}", options.F(OPT_INTERVAL))
+	fmtc.Printfn("  {g}%-14s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.Printfn("  {g}%-14s{!} - Start of time range {s-}(date or duration, e.g. 2025-06-01 18:00 or 2h){!}", options.F(OPT_FROM))
+	fmtc.Printfn("  {g}%-14s{!} - End of time range {s-}(date or duration){!}", options.F(OPT_TO))
+	fmtc.Printfn("  {g}%-14s{!} - Output format {s-}(table/csv, default: table){!}", options.F(OPT_FORMAT))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s %s /var/lib/icecli/history.log", APP, CMD_META_HISTORY, HISTORY_RECORD)
+	fmtc.Printfn("  %s %s %s /var/lib/icecli/history.log /source1.ogg", APP, CMD_META_HISTORY, HISTORY_SHOW)
+	fmtc.Printfn("  %s %s %s %s 6h history.log", APP, CMD_META_HISTORY, HISTORY_SHOW, options.F(OPT_FROM))
+	fmtc.Printfn("  %s %s %s %s \"2025-06-01 18:00\" %s \"2025-06-01 20:00\" %s csv history.log", APP, CMD_META_HISTORY, HISTORY_SHOW, options.F(OPT_FROM), options.F(OPT_TO), options.F(OPT_FORMAT))
+	fmtc.NewLine()
+}
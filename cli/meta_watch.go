@@ -0,0 +1,425 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_TEXT = "text"
+	FORMAT_JSON = "json"
+	FORMAT_XML  = "xml"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// metaFileReader reads track metadata from now-playing file
+type metaFileReader struct {
+	File   string
+	Format string
+	Fields map[string]string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// watchDelay is delay between file change and reading, used for merging
+// events from writers which update file in several steps
+var watchDelay = 250 * time.Millisecond
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// watchMetaFile watches given now-playing file and updates metadata for given
+// mount point on every track change
+func watchMetaFile(mount, file string) {
+	mount = formatMount(mount)
+
+	r, err := newMetaFileReader(file, options.GetS(OPT_FIELDS))
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		printErrorExit("Can't create file watcher: %v", err)
+	}
+
+	defer watcher.Close()
+
+	// Watch the directory instead of the file itself, because most of the
+	// programs replace file using rename
+	err = watcher.Add(filepath.Dir(r.File))
+
+	if err != nil {
+		printErrorExit("Can't watch %s: %v", file, err)
+	}
+
+	log.Info("Watching %s (format: %s) for %s", r.File, r.Format, mount)
+
+	lastTrack := r.Update(mount, trackInfo{})
+	timer := time.NewTimer(watchDelay)
+	timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if filepath.Clean(event.Name) != r.File || event.Has(fsnotify.Chmod) {
+				continue
+			}
+
+			timer.Reset(watchDelay)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			log.Error("File watcher error: %v", err)
+
+		case <-timer.C:
+			lastTrack = r.Update(mount, lastTrack)
+		}
+	}
+}
+
+// newMetaFileReader creates new now-playing file reader
+func newMetaFileReader(file, fields string) (*metaFileReader, error) {
+	file, err := filepath.Abs(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := &metaFileReader{
+		File:   file,
+		Format: guessMetaFileFormat(file),
+		Fields: map[string]string{},
+	}
+
+	if r.Format != FORMAT_TEXT {
+		r.Fields["artist"], r.Fields["title"] = "artist", "title"
+	}
+
+	if fields == "" {
+		return r, nil
+	}
+
+	for _, f := range strings.Split(fields, ",") {
+		name, path, ok := strings.Cut(f, "=")
+		name, path = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(path)
+
+		if !ok || path == "" || !isSupportedMetaField(name) {
+			return nil, fmt.Errorf("Invalid field mapping %q", f)
+		}
+
+		if r.Format == FORMAT_TEXT {
+			line, err := strconv.Atoi(path)
+
+			if err != nil || line < 1 {
+				return nil, fmt.Errorf("Invalid line number in field mapping %q", f)
+			}
+		}
+
+		r.Fields[name] = path
+	}
+
+	return r, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Update reads file and updates metadata if track was changed
+func (r *metaFileReader) Update(mount string, lastTrack trackInfo) trackInfo {
+	track, err := r.Read()
+
+	if err != nil {
+		log.Error("Can't read metadata from %s: %v", r.File, err)
+		return lastTrack
+	}
+
+	if *track == lastTrack {
+		return lastTrack
+	}
+
+	meta := track.ToMeta()
+	err = sendMeta(mount, meta)
+
+	if err != nil {
+		log.Error("Can't update metadata for %s: %v", mount, err)
+		return lastTrack
+	}
+
+	log.Info("Metadata updated for %s: %s", mount, formatTrackMeta(meta))
+
+	return *track
+}
+
+// Read reads track metadata from file
+func (r *metaFileReader) Read() (*trackInfo, error) {
+	data, err := os.ReadFile(r.File)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+
+	var values map[string]string
+
+	switch r.Format {
+	case FORMAT_JSON:
+		values, err = extractJSONFields(data, r.Fields)
+	case FORMAT_XML:
+		values, err = extractXMLFields(data, r.Fields)
+	default:
+		if len(r.Fields) == 0 {
+			return parseMetaLine(firstLine(data))
+		}
+
+		values, err = extractTextFields(data, r.Fields)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	track := &trackInfo{}
+
+	for name, value := range values {
+		track.Set(name, value)
+	}
+
+	if track.IsEmpty() {
+		return nil, fmt.Errorf("Metadata is empty")
+	}
+
+	return track, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// guessMetaFileFormat guesses format of now-playing file using its extension
+func guessMetaFileFormat(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return FORMAT_JSON
+	case ".xml":
+		return FORMAT_XML
+	}
+
+	return FORMAT_TEXT
+}
+
+// extractTextFields extracts fields from text data using line numbers
+func extractTextFields(data []byte, fields map[string]string) (map[string]string, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	result := map[string]string{}
+
+	for name, path := range fields {
+		line, _ := strconv.Atoi(path)
+
+		if line <= len(lines) {
+			result[name] = strings.TrimSpace(lines[line-1])
+		}
+	}
+
+	return result, nil
+}
+
+// extractJSONFields extracts fields from JSON data using dotted paths
+// (e.g. "now.track.artist" or "tracks.0.title")
+func extractJSONFields(data []byte, fields map[string]string) (map[string]string, error) {
+	var doc any
+
+	err := json.Unmarshal(data, &doc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+
+	for name, path := range fields {
+		value := doc
+
+		for _, key := range strings.Split(path, ".") {
+			switch v := value.(type) {
+			case map[string]any:
+				value = v[key]
+			case []any:
+				index, err := strconv.Atoi(key)
+
+				if err != nil || index < 0 || index >= len(v) {
+					value = nil
+				} else {
+					value = v[index]
+				}
+			default:
+				value = nil
+			}
+		}
+
+		switch v := value.(type) {
+		case string:
+			result[name] = strings.TrimSpace(v)
+		case float64:
+			result[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return result, nil
+}
+
+// extractXMLFields extracts fields from XML data using dotted paths of
+// elements names (e.g. "nowplaying.song.artist"). Attributes can be accessed
+// using "@" prefix (e.g. "nowplaying.song.@artist"). Path may omit root element.
+func extractXMLFields(data []byte, fields map[string]string) (map[string]string, error) {
+	var path []string
+
+	values := map[string]string{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+
+			for _, attr := range t.Attr {
+				addXMLValue(values, append(path, "@"+attr.Name.Local), attr.Value)
+			}
+
+		case xml.EndElement:
+			if len(path) != 0 {
+				path = path[:len(path)-1]
+			}
+
+		case xml.CharData:
+			if len(path) != 0 {
+				addXMLValue(values, path, string(t))
+			}
+		}
+	}
+
+	result := map[string]string{}
+
+	for name, path := range fields {
+		result[name] = values[path]
+	}
+
+	return result, nil
+}
+
+// addXMLValue adds value for full path and path without root element
+func addXMLValue(values map[string]string, path []string, value string) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return
+	}
+
+	fullPath := strings.Join(path, ".")
+
+	if values[fullPath] == "" {
+		values[fullPath] = value
+	}
+
+	if len(path) > 1 {
+		shortPath := strings.Join(path[1:], ".")
+
+		if values[shortPath] == "" {
+			values[shortPath] = value
+		}
+	}
+}
+
+// firstLine returns first non-empty line from data
+func firstLine(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdMetaWatch shows help for "meta-watch" command
+func helpCmdMetaWatch() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Watches now-playing file exported by radio automation software and updates")
+	fmtc.Println("  metadata for the mountpoint on every track change. Text, JSON and XML files")
+	fmtc.Println("  are supported, format is detected by file extension.")
+	fmtc.NewLine()
+	fmtc.Println("  By default, the first line of text file must contain \"artist - title\", and")
+	fmtc.Println("  JSON and XML files must contain \"artist\" and \"title\" fields. Mapping can be")
+	fmtc.Println("  changed using fields option with list of \"field=path\" pairs, where path is")
+	fmtc.Println("  line number for text files or dotted path for JSON and XML files.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount file{!}", APP, CMD_META_WATCH)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.Println("  {g}file {!} - Path to now-playing file")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-11s{!} - Fields mapping {s-}(song, artist, title, album, artwork){!}", options.F(OPT_FIELDS))
+	fmtc.Printfn("  {g}%-11s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /source1.ogg /var/lib/playout/nowplaying.txt", APP, CMD_META_WATCH)
+	fmtc.Printfn("  %s %s %s artist=2,title=3 /source1.ogg nowplaying.txt", APP, CMD_META_WATCH, options.F(OPT_FIELDS))
+	fmtc.Printfn("  %s %s %s artist=now.artist,title=now.title /source1.ogg nowplaying.json", APP, CMD_META_WATCH, options.F(OPT_FIELDS))
+	fmtc.Printfn("  %s %s %s artist=song.@artist,title=song.@title /source1.ogg nowplaying.xml", APP, CMD_META_WATCH, options.F(OPT_FIELDS))
+	fmtc.NewLine()
+}
@@ -0,0 +1,289 @@
+package metarules
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ACTION_REPLACE   = "replace"
+	ACTION_STRIP     = "strip"
+	ACTION_CASE      = "case"
+	ACTION_BLACKLIST = "blacklist"
+	ACTION_FALLBACK  = "fallback"
+)
+
+const (
+	CASE_TITLE    = "title"
+	CASE_SENTENCE = "sentence"
+	CASE_UPPER    = "upper"
+	CASE_LOWER    = "lower"
+)
+
+// FIELD_ALL is field name used for rules applied to all text fields
+const FIELD_ALL = "*"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rule is metadata normalization rule
+type Rule struct {
+	Action  string
+	Field   string
+	Pattern *regexp.Regexp
+	Value   string
+}
+
+// Rules is set of metadata normalization rules
+type Rules struct {
+	rules []*Rule
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// fields is list of text metadata fields supported by rules
+var fields = []string{"song", "artist", "title", "album"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LoadFile loads rules from given file
+func LoadFile(file string) (*Rules, error) {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	return Load(fd)
+}
+
+// Load loads rules from given reader
+func Load(r io.Reader) (*Rules, error) {
+	rules := &Rules{}
+
+	line := 0
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		rule, err := parseRule(text)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't parse rule on line %d: %w", line, err)
+		}
+
+		rules.rules = append(rules.rules, rule)
+	}
+
+	if scanner.Err() != nil {
+		return nil, scanner.Err()
+	}
+
+	return rules, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Apply applies rules to track metadata. Rules are applied from top to bottom,
+// fallback rules are applied after all other rules.
+func (r *Rules) Apply(meta ic.TrackMeta) ic.TrackMeta {
+	if r == nil {
+		return meta
+	}
+
+	values := map[string]*string{
+		"song":   &meta.Song,
+		"artist": &meta.Artist,
+		"title":  &meta.Title,
+		"album":  &meta.Album,
+	}
+
+	for _, rule := range r.rules {
+		if rule.Action == ACTION_FALLBACK {
+			continue
+		}
+
+		for _, field := range rule.fields() {
+			v := values[field]
+
+			if *v != "" {
+				*v = rule.apply(*v)
+			}
+		}
+	}
+
+	for _, rule := range r.rules {
+		if rule.Action != ACTION_FALLBACK {
+			continue
+		}
+
+		for _, field := range rule.fields() {
+			if *values[field] == "" {
+				*values[field] = rule.Value
+			}
+		}
+	}
+
+	return meta
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// fields returns list of fields affected by rule
+func (r *Rule) fields() []string {
+	if r.Field == FIELD_ALL {
+		return fields
+	}
+
+	return []string{r.Field}
+}
+
+// apply applies rule to given value
+func (r *Rule) apply(value string) string {
+	switch r.Action {
+	case ACTION_REPLACE:
+		value = r.Pattern.ReplaceAllString(value, r.Value)
+	case ACTION_STRIP:
+		value = r.Pattern.ReplaceAllString(value, " ")
+	case ACTION_BLACKLIST:
+		if r.Pattern.MatchString(value) {
+			return ""
+		}
+	case ACTION_CASE:
+		value = changeCase(value, r.Value)
+	}
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseRule parses rule data
+func parseRule(data string) (*Rule, error) {
+	ruleFields := strings.SplitN(data, "|", 3)
+
+	if len(ruleFields) != 3 {
+		return nil, fmt.Errorf("rule must contain action, field and value")
+	}
+
+	rule := &Rule{
+		Action: strings.ToLower(strings.TrimSpace(ruleFields[0])),
+		Field:  strings.ToLower(strings.TrimSpace(ruleFields[1])),
+		Value:  strings.TrimSpace(ruleFields[2]),
+	}
+
+	if rule.Field != FIELD_ALL && !isSupportedField(rule.Field) {
+		return nil, fmt.Errorf("unsupported field %q", rule.Field)
+	}
+
+	if rule.Value == "" {
+		return nil, fmt.Errorf("value is empty")
+	}
+
+	var err error
+
+	switch rule.Action {
+	case ACTION_REPLACE:
+		pattern, replacement, ok := strings.Cut(rule.Value, " => ")
+
+		if !ok {
+			return nil, fmt.Errorf("replace rule must contain pattern and replacement separated by \" => \"")
+		}
+
+		rule.Pattern, err = regexp.Compile(strings.TrimSpace(pattern))
+		rule.Value = strings.TrimSpace(replacement)
+
+	case ACTION_STRIP:
+		rule.Pattern, err = regexp.Compile(rule.Value)
+
+	case ACTION_BLACKLIST:
+		rule.Pattern, err = regexp.Compile(
+			`(?i)(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(rule.Value) + `($|[^\p{L}\p{N}])`,
+		)
+
+	case ACTION_CASE:
+		rule.Value = strings.ToLower(rule.Value)
+
+		switch rule.Value {
+		case CASE_TITLE, CASE_SENTENCE, CASE_UPPER, CASE_LOWER:
+		default:
+			return nil, fmt.Errorf("unsupported case %q", rule.Value)
+		}
+
+	case ACTION_FALLBACK:
+		if rule.Field != "title" && rule.Field != "artist" {
+			return nil, fmt.Errorf("fallback rule supports only title and artist fields")
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported action %q", rule.Action)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// isSupportedField returns true if field is supported by rules
+func isSupportedField(field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// changeCase changes case of given value
+func changeCase(value, mode string) string {
+	switch mode {
+	case CASE_UPPER:
+		return strings.ToUpper(value)
+	case CASE_LOWER:
+		return strings.ToLower(value)
+	}
+
+	result := []rune(strings.ToLower(value))
+
+	for i, r := range result {
+		switch {
+		case i == 0:
+			result[i] = unicode.ToUpper(r)
+		case mode == CASE_TITLE && isWordSeparator(result[i-1]):
+			result[i] = unicode.ToUpper(r)
+		}
+	}
+
+	return string(result)
+}
+
+// isWordSeparator returns true if given symbol separates words
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("([{-/\"&", r)
+}
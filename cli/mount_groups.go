@@ -0,0 +1,182 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/sliceutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mountGroups is map with mount groups
+type mountGroups map[string][]string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// resolveMounts converts mount name, comma-separated list of names, glob or
+// group name (with "@" prefix) to the list of mount points
+func resolveMounts(target string) ([]string, error) {
+	var result []string
+	var mounts []string
+	var groups mountGroups
+
+	for _, t := range strings.Split(target, ",") {
+		t = strings.TrimSpace(t)
+
+		switch {
+		case t == "":
+			continue
+
+		case strings.HasPrefix(t, "@"):
+			if groups == nil {
+				var err error
+
+				groups, err = readMountGroups(options.GetS(OPT_GROUPS))
+
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			members, ok := groups[strings.TrimPrefix(t, "@")]
+
+			if !ok {
+				return nil, fmt.Errorf("Unknown mount group %q", t)
+			}
+
+			for _, m := range members {
+				resolved, err := resolveMounts(m)
+
+				if err != nil {
+					return nil, err
+				}
+
+				result = append(result, resolved...)
+			}
+
+		case strings.ContainsAny(t, "*?["):
+			if mounts == nil {
+				list, err := client.ListMounts()
+
+				if err != nil {
+					return nil, err
+				}
+
+				for _, m := range list {
+					mounts = append(mounts, m.Path)
+				}
+			}
+
+			pattern := formatMount(t)
+			matched := false
+
+			for _, m := range mounts {
+				ok, err := path.Match(pattern, m)
+
+				if err != nil {
+					return nil, fmt.Errorf("Invalid mount pattern %q: %w", t, err)
+				}
+
+				if ok {
+					result = append(result, m)
+					matched = true
+				}
+			}
+
+			if !matched {
+				return nil, fmt.Errorf("No mounts found for pattern %q", t)
+			}
+
+		default:
+			result = append(result, formatMount(t))
+		}
+	}
+
+	var uniq []string
+
+	for _, m := range result {
+		if !sliceutil.Contains(uniq, m) {
+			uniq = append(uniq, m)
+		}
+	}
+
+	return uniq, nil
+}
+
+// readMountGroups reads mount groups from file. Every line of the file must
+// contain group name and list of mount names or globs separated by "|".
+func readMountGroups(file string) (mountGroups, error) {
+	if file == "" {
+		return nil, fmt.Errorf("Mount groups file is not set (use %s option)", options.F(OPT_GROUPS))
+	}
+
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read mount groups: %w", err)
+	}
+
+	defer fd.Close()
+
+	line := 0
+	groups := mountGroups{}
+	scanner := bufio.NewScanner(fd)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		name, members, ok := strings.Cut(text, "|")
+		name, members = strings.TrimSpace(name), strings.TrimSpace(members)
+
+		if !ok || name == "" || members == "" {
+			return nil, fmt.Errorf("Can't parse mount group on line %d", line)
+		}
+
+		for _, m := range strings.Fields(members) {
+			if strings.HasPrefix(m, "@") {
+				return nil, fmt.Errorf("Mount group %q can't contain other groups", name)
+			}
+
+			groups[name] = append(groups[name], m)
+		}
+	}
+
+	return groups, scanner.Err()
+}
+
+// isMountsTarget returns true if given target can be resolved to several
+// mount points
+func isMountsTarget(target string) bool {
+	return strings.ContainsAny(target, ",@*?[")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// printMountTargetsHelp prints help about mount groups and globs
+func printMountTargetsHelp() {
+	fmtc.Println("  Mount can be a comma-separated list of names, glob (e.g. /jazz*) or group name")
+	fmtc.Println("  with \"@\" prefix. Groups are defined in file set by --groups option, where")
+	fmtc.Println("  every line contains group name and list of mounts or globs:")
+	fmtc.NewLine()
+	fmtc.Println("    {s}jazz | /jazz.mp3 /jazz.aac /jazz.opus{!}")
+	fmtc.Println("    {s}rock | /rock*{!}")
+	fmtc.NewLine()
+}
@@ -0,0 +1,194 @@
+package mpd
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Client is minimal MPD client
+type Client struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	Version string
+}
+
+// Attrs contains attributes from MPD response
+type Attrs map[string]string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Dial connects to MPD server. Address may contain password in MPD_HOST
+// format (password@host:port).
+func Dial(address string, timeout time.Duration) (*Client, error) {
+	var password string
+
+	if strings.Contains(address, "@") {
+		password, address, _ = strings.Cut(address, "@")
+	}
+
+	if !strings.Contains(address, ":") {
+		address += ":6600"
+	}
+
+	conn, err := net.DialTimeout("tcp", address, timeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{conn: conn, reader: bufio.NewReader(conn)}
+
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	greeting, err := c.readLine()
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if !strings.HasPrefix(greeting, "OK MPD ") {
+		conn.Close()
+		return nil, fmt.Errorf("Unexpected MPD greeting %q", greeting)
+	}
+
+	c.Version = strings.TrimPrefix(greeting, "OK MPD ")
+
+	if password != "" {
+		_, err = c.Command("password", password)
+
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Idle waits until something changed in one of given subsystems and returns
+// list of changed subsystems
+func (c *Client) Idle(subsystems ...string) ([]string, error) {
+	c.conn.SetDeadline(time.Time{})
+
+	err := c.send("idle", subsystems...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+
+	err = c.readResponse(func(key, value string) {
+		if key == "changed" {
+			changed = append(changed, value)
+		}
+	})
+
+	return changed, err
+}
+
+// CurrentSong returns info about current song
+func (c *Client) CurrentSong() (Attrs, error) {
+	return c.Command("currentsong")
+}
+
+// Command sends command to the server and returns attributes from response
+func (c *Client) Command(cmd string, args ...string) (Attrs, error) {
+	c.conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	err := c.send(cmd, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := Attrs{}
+
+	err = c.readResponse(func(key, value string) {
+		if _, ok := attrs[key]; !ok {
+			attrs[key] = value
+		}
+	})
+
+	return attrs, err
+}
+
+// Close closes connection to the server
+func (c *Client) Close() error {
+	if c == nil || c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// send sends command with arguments to the server
+func (c *Client) send(cmd string, args ...string) error {
+	var buf strings.Builder
+
+	buf.WriteString(cmd)
+
+	for _, arg := range args {
+		buf.WriteString(" \"")
+		buf.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg))
+		buf.WriteString("\"")
+	}
+
+	buf.WriteString("\n")
+
+	_, err := c.conn.Write([]byte(buf.String()))
+
+	return err
+}
+
+// readResponse reads response until OK or ACK line
+func (c *Client) readResponse(handler func(key, value string)) error {
+	for {
+		line, err := c.readLine()
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "OK":
+			return nil
+		case strings.HasPrefix(line, "ACK "):
+			return fmt.Errorf("MPD error: %s", strings.TrimPrefix(line, "ACK "))
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+
+		if ok {
+			handler(key, value)
+		}
+	}
+}
+
+// readLine reads line from connection
+func (c *Client) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
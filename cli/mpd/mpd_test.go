@@ -0,0 +1,389 @@
+package mpd
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// fakeServer is fake MPD server speaking text protocol
+type fakeServer struct {
+	listener net.Listener
+	password string
+	changes  chan string // Subsystems reported to idle clients
+	done     chan struct{}
+
+	mu       sync.Mutex
+	song     string
+	conns    []net.Conn
+	commands []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const testSong = "file: music/track.flac\n" +
+	"Artist: Example Artist\n" +
+	"Title: Example: Title\n" +
+	"Album: Example Album\n" +
+	"Artist: Second Artist\n" +
+	"Pos: 0\n"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestDial(t *testing.T) {
+	s := startFakeServer(t, "")
+
+	c, err := Dial(s.Addr(), time.Second)
+
+	if err != nil {
+		t.Fatalf("Can't connect to server: %v", err)
+	}
+
+	defer c.Close()
+
+	if c.Version != "0.23.5" {
+		t.Errorf("Unexpected version %q", c.Version)
+	}
+}
+
+func TestDialPassword(t *testing.T) {
+	s := startFakeServer(t, "secret")
+
+	c, err := Dial("secret@"+s.Addr(), time.Second)
+
+	if err != nil {
+		t.Fatalf("Can't connect to server: %v", err)
+	}
+
+	c.Close()
+
+	if s.LastCommand() != `password "secret"` {
+		t.Errorf("Unexpected command %q", s.LastCommand())
+	}
+
+	_, err = Dial("wrong@"+s.Addr(), time.Second)
+
+	if err == nil || !strings.Contains(err.Error(), "incorrect password") {
+		t.Errorf("Expected password error, got %v", err)
+	}
+}
+
+func TestDialGreeting(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+
+		if err == nil {
+			conn.Write([]byte("HTTP/1.1 400 Bad Request\n"))
+			conn.Close()
+		}
+	}()
+
+	_, err = Dial(l.Addr().String(), time.Second)
+
+	if err == nil || !strings.Contains(err.Error(), "Unexpected MPD greeting") {
+		t.Errorf("Expected greeting error, got %v", err)
+	}
+}
+
+func TestCurrentSong(t *testing.T) {
+	s := startFakeServer(t, "")
+	c := dialFakeServer(t, s)
+
+	song, err := c.CurrentSong()
+
+	if err != nil {
+		t.Fatalf("Can't get current song: %v", err)
+	}
+
+	expected := Attrs{
+		"file":   "music/track.flac",
+		"Artist": "Example Artist",
+		"Title":  "Example: Title",
+		"Album":  "Example Album",
+		"Pos":    "0",
+	}
+
+	if len(song) != len(expected) {
+		t.Errorf("Unexpected attributes %v", song)
+	}
+
+	for k, v := range expected {
+		if song[k] != v {
+			t.Errorf("Unexpected %s value %q (expected %q)", k, song[k], v)
+		}
+	}
+
+	s.SetSong("")
+
+	song, err = c.CurrentSong()
+
+	if err != nil || len(song) != 0 {
+		t.Errorf("Expected empty song, got %v (%v)", song, err)
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	s := startFakeServer(t, "")
+	c := dialFakeServer(t, s)
+
+	_, err := c.Command("unknown")
+
+	if err == nil || err.Error() != `MPD error: [5@0] {} unknown command "unknown"` {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	// Connection must be usable after error
+	_, err = c.CurrentSong()
+
+	if err != nil {
+		t.Errorf("Can't get current song after error: %v", err)
+	}
+}
+
+func TestIdle(t *testing.T) {
+	s := startFakeServer(t, "")
+	c := dialFakeServer(t, s)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		s.changes <- "player"
+	}()
+
+	changed, err := c.Idle("player")
+
+	if err != nil {
+		t.Fatalf("Idle returned error: %v", err)
+	}
+
+	if len(changed) != 1 || changed[0] != "player" {
+		t.Errorf("Unexpected changed subsystems %v", changed)
+	}
+
+	if s.LastCommand() != `idle "player"` {
+		t.Errorf("Unexpected command %q", s.LastCommand())
+	}
+
+	// Idle must not be limited by deadline of previous commands
+	c.CurrentSong()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		s.changes <- "player"
+	}()
+
+	_, err = c.Idle("player")
+
+	if err != nil {
+		t.Errorf("Second idle returned error: %v", err)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	s := startFakeServer(t, "")
+	c := dialFakeServer(t, s)
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		_, err := c.Idle("player")
+		errCh <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	s.DropConnections()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("Idle must return error if connection is closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Idle wasn't interrupted by closed connection")
+	}
+
+	c.Close()
+
+	c, err := Dial(s.Addr(), time.Second)
+
+	if err != nil {
+		t.Fatalf("Can't reconnect to server: %v", err)
+	}
+
+	defer c.Close()
+
+	song, err := c.CurrentSong()
+
+	if err != nil || song["Title"] != "Example: Title" {
+		t.Errorf("Unexpected song after reconnect %v (%v)", song, err)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startFakeServer starts fake MPD server
+func startFakeServer(t *testing.T, password string) *fakeServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("Can't start fake server: %v", err)
+	}
+
+	s := &fakeServer{
+		listener: l,
+		password: password,
+		changes:  make(chan string),
+		done:     make(chan struct{}),
+		song:     testSong,
+	}
+
+	t.Cleanup(func() {
+		close(s.done)
+		l.Close()
+		s.DropConnections()
+	})
+
+	go s.serve()
+
+	return s
+}
+
+// dialFakeServer connects to fake server
+func dialFakeServer(t *testing.T, s *fakeServer) *Client {
+	c, err := Dial(s.Addr(), time.Second)
+
+	if err != nil {
+		t.Fatalf("Can't connect to server: %v", err)
+	}
+
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Addr returns server address
+func (s *fakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// SetSong sets response of currentsong command
+func (s *fakeServer) SetSong(song string) {
+	s.mu.Lock()
+	s.song = song
+	s.mu.Unlock()
+}
+
+// LastCommand returns last received command
+func (s *fakeServer) LastCommand() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.commands) == 0 {
+		return ""
+	}
+
+	return s.commands[len(s.commands)-1]
+}
+
+// DropConnections closes all client connections
+func (s *fakeServer) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+
+	s.conns = nil
+}
+
+// serve accepts connections
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// handle handles commands from client
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	conn.Write([]byte("OK MPD 0.23.5\n"))
+
+	r := bufio.NewReader(conn)
+
+	for {
+		line, err := r.ReadString('\n')
+
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		cmd, _, _ := strings.Cut(line, " ")
+
+		s.mu.Lock()
+		s.commands = append(s.commands, line)
+		song := s.song
+		s.mu.Unlock()
+
+		var resp string
+
+		switch cmd {
+		case "password":
+			if line == `password "`+s.password+`"` {
+				resp = "OK\n"
+			} else {
+				resp = "ACK [3@0] {password} incorrect password\n"
+			}
+		case "currentsong":
+			resp = song + "OK\n"
+		case "idle":
+			select {
+			case subsystem := <-s.changes:
+				resp = "changed: " + subsystem + "\nOK\n"
+			case <-s.done:
+				return
+			}
+		default:
+			resp = "ACK [5@0] {} unknown command \"" + cmd + "\"\n"
+		}
+
+		_, err = conn.Write([]byte(resp))
+
+		if err != nil {
+			return
+		}
+	}
+}
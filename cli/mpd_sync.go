@@ -0,0 +1,169 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/essentialkaos/icecli/cli/mpd"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mpdSyncer pushes metadata of current MPD song to mount points
+type mpdSyncer struct {
+	Address string
+	Mounts  []string
+
+	lastTrack trackInfo
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mpdReconnectDelay is delay between reconnects to MPD server
+var mpdReconnectDelay = 5 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startMPDSync starts metadata synchronization with MPD
+func startMPDSync(mounts []string) {
+	s := &mpdSyncer{Address: options.GetS(OPT_MPD)}
+
+	for _, m := range mounts {
+		s.Mounts = append(s.Mounts, formatMount(m))
+	}
+
+	err := setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	for {
+		err = s.Sync()
+
+		log.Error("MPD connection error: %v", err)
+		log.Info("Reconnecting to MPD in %v…", mpdReconnectDelay)
+
+		time.Sleep(mpdReconnectDelay)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Sync connects to MPD server and pushes metadata on every player event
+func (s *mpdSyncer) Sync() error {
+	mc, err := mpd.Dial(s.Address, 10*time.Second)
+
+	if err != nil {
+		return err
+	}
+
+	defer mc.Close()
+
+	log.Info("Connected to MPD %s (%s), syncing metadata to %s", s.Address, mc.Version, strings.Join(s.Mounts, ", "))
+
+	for {
+		song, err := mc.CurrentSong()
+
+		if err != nil {
+			return err
+		}
+
+		s.Push(songToTrack(song))
+
+		_, err = mc.Idle("player")
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Push pushes track metadata to all mount points if track was changed
+func (s *mpdSyncer) Push(track *trackInfo) {
+	if track == nil || *track == s.lastTrack {
+		return
+	}
+
+	meta := track.ToMeta()
+	hasErrors := false
+
+	for _, res := range sendMetaToMounts(s.Mounts, meta) {
+		if res.Err != nil {
+			log.Error("Can't update metadata for %s: %v", res.Mount, res.Err)
+			hasErrors = true
+			continue
+		}
+
+		log.Info("Metadata updated for %s: %s", res.Mount, formatTrackMeta(meta))
+	}
+
+	// Keep previous track on error, so update will be retried on next event
+	if !hasErrors {
+		s.lastTrack = *track
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// songToTrack converts MPD song info to track info
+func songToTrack(song mpd.Attrs) *trackInfo {
+	track := &trackInfo{
+		Artist: song["Artist"],
+		Title:  song["Title"],
+		Album:  song["Album"],
+	}
+
+	if track.Title == "" {
+		track.Title = song["Name"]
+	}
+
+	if track.Title == "" && song["file"] != "" {
+		file := path.Base(song["file"])
+		track.Title = strings.TrimSuffix(file, path.Ext(file))
+	}
+
+	if track.Artist == "" && track.Title == "" {
+		return nil
+	}
+
+	return track
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdMPDSync shows help for "mpd-sync" command
+func helpCmdMPDSync() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Connects to MPD server, listens for player events using idle protocol and")
+	fmtc.Println("  updates metadata for one or more mountpoints on every song change. Connection")
+	fmtc.Println("  is restored automatically if MPD server goes away.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount…{!}", APP, CMD_MPD_SYNC)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-8s{!} - MPD address {s-}([password@]host:port, default: 127.0.0.1:6600){!}", options.F(OPT_MPD))
+	fmtc.Printfn("  {g}%-8s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /source1.ogg /source1.mp3", APP, CMD_MPD_SYNC)
+	fmtc.Printfn("  %s %s %s secret@10.0.0.5:6600 /source1.ogg", APP, CMD_MPD_SYNC, options.F(OPT_MPD))
+	fmtc.NewLine()
+}
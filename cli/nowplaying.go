@@ -0,0 +1,348 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NOWPLAYING_PATH is base path of now-playing endpoints
+const NOWPLAYING_PATH = "/nowplaying"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// nowPlaying contains public info about mount point
+type nowPlaying struct {
+	Mount         string    `json:"mount"`
+	Artist        string    `json:"artist"`
+	Title         string    `json:"title"`
+	Artwork       string    `json:"artwork"`
+	Listeners     int       `json:"listeners"`
+	StreamStarted time.Time `json:"stream_started"`
+}
+
+// nowPlayingPayload is encoded response with its ETag
+type nowPlayingPayload struct {
+	Data []byte
+	ETag string
+}
+
+// nowPlayingServer is HTTP server with now-playing info
+type nowPlayingServer struct {
+	Interval time.Duration
+	Origin   string
+	SSE      bool
+
+	mx       sync.RWMutex
+	payloads map[string]*nowPlayingPayload
+	changed  chan struct{}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sseKeepAliveInterval is interval between keep-alive comments in SSE streams
+var sseKeepAliveInterval = 15 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// serveNowPlaying starts HTTP server with now-playing info
+func serveNowPlaying() {
+	s := &nowPlayingServer{
+		Origin:  options.GetS(OPT_CORS),
+		SSE:     options.GetB(OPT_SSE),
+		changed: make(chan struct{}),
+	}
+
+	var err error
+
+	s.Interval, err = parseInterval()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = s.Update()
+
+	if err != nil {
+		log.Error("Can't get server stats: %v", err)
+	}
+
+	go s.Poll()
+
+	log.Info(
+		"Now-playing server started on %s (interval: %s, SSE: %t)",
+		options.GetS(OPT_LISTEN), timeutil.PrettyDuration(s.Interval), s.SSE,
+	)
+
+	err = http.ListenAndServe(options.GetS(OPT_LISTEN), s)
+
+	if err != nil {
+		printErrorExit("Can't start HTTP server: %v", err)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Poll periodically updates now-playing info
+func (s *nowPlayingServer) Poll() {
+	for range time.Tick(s.Interval) {
+		err := s.Update()
+
+		if err != nil {
+			log.Error("Can't get server stats: %v", err)
+		}
+	}
+}
+
+// Update fetches server stats and updates payloads
+func (s *nowPlayingServer) Update() error {
+	stats, err := client.GetStats()
+
+	if err != nil {
+		return err
+	}
+
+	var all []*nowPlaying
+
+	payloads := map[string]*nowPlayingPayload{}
+
+	for mount, source := range stats.Sources {
+		info := &nowPlaying{Mount: mount, StreamStarted: source.StreamStarted}
+
+		if source.Track != nil {
+			info.Artist = source.Track.Artist
+			info.Title = source.Track.Title
+			info.Artwork = source.Track.Artwork
+		}
+
+		if source.Stats != nil {
+			info.Listeners = source.Stats.Listeners
+		}
+
+		payloads[mount], err = newNowPlayingPayload(info)
+
+		if err != nil {
+			return err
+		}
+
+		all = append(all, info)
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Mount < all[j].Mount })
+
+	if all == nil {
+		all = []*nowPlaying{}
+	}
+
+	payloads[""], err = newNowPlayingPayload(all)
+
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	s.payloads = payloads
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.mx.Unlock()
+
+	return nil
+}
+
+// ServeHTTP serves now-playing requests
+func (s *nowPlayingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != NOWPLAYING_PATH && !strings.HasPrefix(r.URL.Path, NOWPLAYING_PATH+"/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	mount := strings.TrimPrefix(r.URL.Path, NOWPLAYING_PATH)
+
+	if s.Origin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.Origin)
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "If-None-Match, Last-Event-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	}
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodHead:
+		// ok
+	default:
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.SSE && strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		s.serveEvents(w, r, mount)
+		return
+	}
+
+	payload, _ := s.get(mount)
+
+	if payload == nil {
+		s.notFound(w, mount)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.Interval.Seconds())))
+	w.Header().Set("ETag", payload.ETag)
+
+	if r.Header.Get("If-None-Match") == payload.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	w.Write(payload.Data)
+}
+
+// serveEvents streams now-playing updates using Server-Sent Events
+func (s *nowPlayingServer) serveEvents(w http.ResponseWriter, r *http.Request, mount string) {
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	payload, changed := s.get(mount)
+
+	if payload == nil {
+		s.notFound(w, mount)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	lastETag := r.Header.Get("Last-Event-ID")
+
+	for {
+		if payload != nil && payload.ETag != lastETag {
+			fmt.Fprintf(w, "id: %s\ndata: %s\n\n", payload.ETag, payload.Data)
+			flusher.Flush()
+			lastETag = payload.ETag
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case <-changed:
+			payload, changed = s.get(mount)
+		}
+	}
+}
+
+// get returns payload for given mount (or all mounts if mount is empty) and
+// channel which will be closed on next update
+func (s *nowPlayingServer) get(mount string) (*nowPlayingPayload, chan struct{}) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return s.payloads[mount], s.changed
+}
+
+// notFound writes error for unknown mount point
+func (s *nowPlayingServer) notFound(w http.ResponseWriter, mount string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusNotFound)
+
+	data, _ := json.Marshal(map[string]string{"error": "Mount " + mount + " not found"})
+	w.Write(data)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newNowPlayingPayload encodes data and calculates its ETag
+func newNowPlayingPayload(data any) (*nowPlayingPayload, error) {
+	jsonData, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hash := fnv.New64a()
+	hash.Write(jsonData)
+
+	return &nowPlayingPayload{
+		Data: jsonData,
+		ETag: fmt.Sprintf("\"%x\"", hash.Sum64()),
+	}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdServeNowPlaying shows help for "serve-nowplaying" command
+func helpCmdServeNowPlaying() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Starts HTTP server with now-playing info for websites and apps. Server polls")
+	fmtc.Println("  Icecast stats and provides only artist, title, artwork, number of listeners")
+	fmtc.Println("  and stream start time for every mountpoint.")
+	fmtc.NewLine()
+	fmtc.Println("  Endpoints:")
+	fmtc.NewLine()
+	fmtc.Println("    {s}/nowplaying{!}         {s-}— Info about all mountpoints{!}")
+	fmtc.Println("    {s}/nowplaying/{mount}{!} {s-}— Info about mountpoint (e.g. /nowplaying/source1.ogg){!}")
+	fmtc.NewLine()
+	fmtc.Println("  Responses contain CORS, Cache-Control and ETag headers. With --sse option,")
+	fmtc.Println("  updates are pushed using Server-Sent Events to clients which request")
+	fmtc.Println("  text/event-stream (e.g. EventSource in browsers).")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!}", APP, CMD_SERVE_NOWPLAYING)
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-14s{!} - Address to listen {s-}(default: 127.0.0.1:8080){!}", options.F(OPT_LISTEN))
+	fmtc.Printfn("  {g}%-14s{!} - Allowed CORS origin {s-}(default: *){!}", options.F(OPT_CORS))
+	fmtc.Printfn("  {g}%-14s{!} - Push updates using Server-Sent Events", options.F(OPT_SSE))
+	fmtc.Printfn("  {g}%-14s{!} - Stats polling interval {s-}(default: 5s){!}", options.F(OPT_INTERVAL))
+	fmtc.Printfn("  {g}%-14s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s", APP, CMD_SERVE_NOWPLAYING)
+	fmtc.Printfn("  %s %s %s 0.0.0.0:8080 %s https://radio.example.com %s", APP, CMD_SERVE_NOWPLAYING, options.F(OPT_LISTEN), options.F(OPT_CORS), options.F(OPT_SSE))
+	fmtc.NewLine()
+}
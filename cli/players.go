@@ -0,0 +1,175 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/mathutil"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/essentialkaos/icecli/cli/ua"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// familyStat contains number of listeners using some client family on some
+// mount point
+type familyStat struct {
+	Mount     string
+	Family    string
+	Listeners int
+	Total     int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// uaParser is user agent parser
+var uaParser *ua.Parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// showPlayers prints players, apps, OS or devices breakdown per mount point
+func showPlayers(mount string) {
+	groupBy := strings.ToLower(options.GetS(OPT_GROUP_BY))
+
+	if !ua.IsValidType(groupBy) {
+		printErrorExit("Unsupported %s value %q", options.F(OPT_GROUP_BY), groupBy)
+	}
+
+	err := initUAParser()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	if mount != "" {
+		mount = formatMount(mount)
+	}
+
+	clients, err := listMountsClients(mount)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	if len(clients) == 0 {
+		fmtc.Println("{y}No listeners found{!}")
+		return
+	}
+
+	t := table.NewTable("mount", groupBy, "listeners", "share")
+	t.SetAlignments(table.ALIGN_LEFT, table.ALIGN_LEFT, table.ALIGN_RIGHT, table.ALIGN_RIGHT)
+	t.SetSizes(20, 24, 10, 8)
+
+	fmtc.NewLine()
+
+	for _, s := range aggregateFamilyStats(clients, groupBy) {
+		t.Print(
+			s.Mount, s.Family, fmtutil.PrettyNum(s.Listeners),
+			fmtutil.PrettyPerc(mathutil.Perc(s.Listeners, s.Total)),
+		)
+	}
+
+	t.Separator()
+
+	fmtc.NewLine()
+}
+
+// aggregateFamilyStats groups clients by mount point and client family of
+// given type
+func aggregateFamilyStats(clients []*mountClient, typ string) []*familyStat {
+	index := map[string]*familyStat{}
+	totals := map[string]int{}
+
+	for _, c := range clients {
+		family := uaParser.Parse(c.UserAgent).Get(typ)
+		key := c.Mount + "|" + family
+
+		if index[key] == nil {
+			index[key] = &familyStat{Mount: c.Mount, Family: family}
+		}
+
+		index[key].Listeners++
+		totals[c.Mount]++
+	}
+
+	var result []*familyStat
+
+	for _, s := range index {
+		s.Total = totals[s.Mount]
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Mount != result[j].Mount {
+			return result[i].Mount < result[j].Mount
+		}
+
+		if result[i].Listeners != result[j].Listeners {
+			return result[i].Listeners > result[j].Listeners
+		}
+
+		return result[i].Family < result[j].Family
+	})
+
+	return result
+}
+
+// initUAParser initializes user agent parser
+func initUAParser() error {
+	uaParser = ua.NewParser()
+
+	if !options.Has(OPT_UA_RULES) {
+		return nil
+	}
+
+	err := uaParser.LoadFile(options.GetS(OPT_UA_RULES))
+
+	if err != nil {
+		return fmt.Errorf("Can't load user agent rules: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdPlayers shows help for "players" command
+func helpCmdPlayers() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Shows percentage of listeners per player, app, OS or device family for")
+	fmtc.Println("  every mountpoint. Families are detected by user agent using embedded")
+	fmtc.Println("  rule set, which can be extended with custom rules.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_PLAYERS)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(optional, with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-10s{!} - Family type {s-}(player/app/os/device, default: player){!}", options.F(OPT_GROUP_BY))
+	fmtc.Printfn("  {g}%-10s{!} - Path to file with custom rules", options.F(OPT_UA_RULES))
+	fmtc.NewLine()
+	fmtc.Println("{*}Rules format:{!}\n")
+	fmtc.Println("  {s}# type | family | regular expression{!}")
+	fmtc.Println("  app    | Our Car App | (?i)ourcarapp/1\\.")
+	fmtc.Println("  device | Car         | (?i)ourcarapp")
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s", APP, CMD_PLAYERS)
+	fmtc.Printfn("  %s %s %s app %s my-rules.txt /source1.aac", APP, CMD_PLAYERS, options.F(OPT_GROUP_BY), options.F(OPT_UA_RULES))
+	fmtc.NewLine()
+}
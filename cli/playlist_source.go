@@ -0,0 +1,386 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+	"github.com/essentialkaos/icecli/cli/source"
+	"github.com/essentialkaos/icecli/cli/tags"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// playlistSource streams files from playlist to mount point
+type playlistSource struct {
+	Mount  string
+	Format string
+	Tracks []string
+
+	conn  *source.Conn
+	pacer *audio.Pacer
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// errSourceWrite is returned if audio data can't be sent to the server
+var errSourceWrite = errors.New("Can't send data to the server")
+
+// sourceReconnectDelay is delay between reconnection attempts
+var sourceReconnectDelay = 5 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startPlaylistSource streams files from M3U playlist or directory to the
+// mount point
+func startPlaylistSource(mount, playlist string) {
+	err := setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	tracks, err := loadPlaylist(playlist)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	if len(tracks) == 0 {
+		printErrorExit("Playlist %s doesn't contain MP3 or Ogg files", playlist)
+	}
+
+	s := &playlistSource{
+		Mount:  formatMount(mount),
+		Format: audio.FormatByExt(tracks[0]),
+		Tracks: tracks,
+	}
+
+	log.Info(
+		"Streaming %d tracks from %s to %s (shuffle: %t, loop: %t)",
+		len(tracks), playlist, s.Mount, options.GetB(OPT_SHUFFLE), options.GetB(OPT_LOOP),
+	)
+
+	err = s.Run()
+
+	if err != nil {
+		log.Crit(err.Error())
+		printErrorExit(err.Error())
+	}
+
+	log.Info("Playlist finished")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run streams all tracks from playlist
+func (s *playlistSource) Run() error {
+	defer func() { s.conn.Close() }()
+
+	for {
+		tracks := slices.Clone(s.Tracks)
+
+		if options.GetB(OPT_SHUFFLE) {
+			rand.Shuffle(len(tracks), func(i, j int) {
+				tracks[i], tracks[j] = tracks[j], tracks[i]
+			})
+		}
+
+		played := 0
+
+		for _, track := range tracks {
+			if s.Play(track) {
+				played++
+			}
+		}
+
+		if played == 0 {
+			return fmt.Errorf("No playable tracks in playlist")
+		}
+
+		if !options.GetB(OPT_LOOP) {
+			return nil
+		}
+	}
+}
+
+// Play streams given track to the mount point. Returns true if track was
+// successfully played.
+func (s *playlistSource) Play(track string) bool {
+	if audio.FormatByExt(track) != s.Format {
+		log.Warn("Track %s skipped: format differs from stream format (%s)", track, s.Format)
+		return false
+	}
+
+	for {
+		err := s.stream(track)
+
+		switch {
+		case err == nil:
+			return true
+		case !errors.Is(err, errSourceWrite):
+			log.Error("Track %s skipped: %v", track, err)
+			return false
+		}
+
+		log.Error("%v, reconnecting in %s…", err, sourceReconnectDelay)
+
+		s.conn.Close()
+		s.conn = nil
+
+		time.Sleep(sourceReconnectDelay)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// stream streams track to the server
+func (s *playlistSource) stream(track string) error {
+	fd, err := os.Open(track)
+
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	reader, err := audio.NewReader(fd, s.Format)
+
+	if err != nil {
+		return err
+	}
+
+	chunk, err := reader.Read()
+
+	if err != nil {
+		return fmt.Errorf("Can't read audio data: %w", err)
+	}
+
+	if s.conn == nil {
+		err = s.connect(chunk)
+
+		if err != nil {
+			return fmt.Errorf("%w: %v", errSourceWrite, err)
+		}
+	}
+
+	log.Info("Playing %s", track)
+
+	go s.updateMeta(track)
+
+	for {
+		s.pacer.Wait(chunk.Duration)
+
+		_, err = s.conn.Write(chunk.Data)
+
+		if err != nil {
+			return fmt.Errorf("%w: %v", errSourceWrite, err)
+		}
+
+		chunk, err = reader.Read()
+
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return fmt.Errorf("Can't read audio data: %w", err)
+		}
+	}
+}
+
+// connect connects to the server as a source client
+func (s *playlistSource) connect(chunk *audio.Chunk) error {
+	conn, err := source.Connect(&source.Config{
+		URL:         getHostURL(),
+		Mount:       s.Mount,
+		User:        options.GetS(OPT_SOURCE_USER),
+		Password:    options.GetS(OPT_SOURCE_PASS),
+		ContentType: audio.ContentType(s.Format),
+		Timeout:     streamTimeout,
+		Info:        getSourceInfo(chunk),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Info("Connected to %s using %s method", s.Mount, conn.Method)
+
+	s.conn = conn
+	s.pacer = &audio.Pacer{Lead: pushLead}
+
+	return nil
+}
+
+// updateMeta sends track metadata from tags to the server
+func (s *playlistSource) updateMeta(track string) {
+	meta := ic.TrackMeta{}
+	t, err := tags.ReadFile(track)
+
+	if err == nil && !t.IsEmpty() {
+		meta.Artist, meta.Title, meta.Album = t.Artist, t.Title, t.Album
+	} else {
+		meta.Title = strings.TrimSuffix(filepath.Base(track), filepath.Ext(track))
+	}
+
+	err = sendMeta(s.Mount, meta)
+
+	if err != nil {
+		log.Error("Can't update metadata for %s: %v", s.Mount, err)
+		return
+	}
+
+	log.Info("Metadata updated: %s", formatTrackMeta(meta))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// loadPlaylist loads list of tracks from M3U playlist or directory
+func loadPlaylist(playlist string) ([]string, error) {
+	info, err := os.Stat(playlist)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read playlist: %w", err)
+	}
+
+	if info.IsDir() {
+		return readPlaylistDir(playlist)
+	}
+
+	return readPlaylistFile(playlist)
+}
+
+// readPlaylistDir returns all supported audio files from directory sorted
+// by path
+func readPlaylistDir(dir string) ([]string, error) {
+	var tracks []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && isPlaylistTrack(path) {
+			tracks = append(tracks, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read directory %s: %w", dir, err)
+	}
+
+	slices.Sort(tracks)
+
+	return tracks, nil
+}
+
+// readPlaylistFile reads tracks from M3U playlist. Relative paths are resolved
+// relative to playlist directory.
+func readPlaylistFile(file string) ([]string, error) {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't open playlist: %w", err)
+	}
+
+	defer fd.Close()
+
+	var tracks []string
+
+	dir := filepath.Dir(file)
+	scanner := bufio.NewScanner(fd)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\uFEFF"))
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.Contains(line, "://") {
+			log.Warn("Playlist entry %s skipped: only local files are supported", line)
+			continue
+		}
+
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(dir, line)
+		}
+
+		if isPlaylistTrack(line) {
+			tracks = append(tracks, line)
+		}
+	}
+
+	if scanner.Err() != nil {
+		return nil, fmt.Errorf("Can't read playlist: %w", scanner.Err())
+	}
+
+	return tracks, nil
+}
+
+// isPlaylistTrack returns true if file can be streamed by playlist source
+func isPlaylistTrack(file string) bool {
+	switch audio.FormatByExt(file) {
+	case audio.FORMAT_MP3, audio.FORMAT_OGG:
+		return true
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdPlaylistSource shows help for "playlist-source" command
+func helpCmdPlaylistSource() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Streams MP3 or Ogg files from M3U playlist or directory to the mountpoint one")
+	fmtc.Println("  by one using a single source connection. On every track change, metadata from")
+	fmtc.Println("  ID3v2/ID3v1 tags or Vorbis comments is sent to the server. If file doesn't")
+	fmtc.Println("  contain tags, file name is used as title. All files must have the same format")
+	fmtc.Println("  as the first track, other files are skipped.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!} {g}playlist{!}", APP, CMD_PLAYLIST_SOURCE)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!}    - Mount name {s-}(with or without leading slash){!}")
+	fmtc.Println("  {g}playlist{!} - Path to M3U playlist or directory with audio files")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-20s{!} - Shuffle tracks", options.F(OPT_SHUFFLE))
+	fmtc.Printfn("  {g}%-20s{!} - Play playlist in a loop", options.F(OPT_LOOP))
+	fmtc.Printfn("  {g}%-20s{!} - Source user {s-}(default: source){!}", options.F(OPT_SOURCE_USER))
+	fmtc.Printfn("  {g}%-20s{!} - Source password {s-}(default: hackme){!}", options.F(OPT_SOURCE_PASS))
+	fmtc.Printfn("  {g}%-20s{!} - Stream name", options.F(OPT_STREAM_NAME))
+	fmtc.Printfn("  {g}%-20s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /fallback.mp3 /srv/music/emergency.m3u", APP, CMD_PLAYLIST_SOURCE)
+	fmtc.Printfn("  %s %s %s %s /fallback.ogg /srv/music/ogg", APP, CMD_PLAYLIST_SOURCE, options.F(OPT_SHUFFLE), options.F(OPT_LOOP))
+	fmtc.NewLine()
+}
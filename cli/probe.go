@@ -0,0 +1,439 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// probeResult contains stream measurements
+type probeResult struct {
+	ContentType   string
+	ConnectTime   time.Duration
+	FirstByteTime time.Duration
+	Elapsed       time.Duration
+	Received      int64
+
+	Codec      string
+	SampleRate int
+	Channels   int
+	MinBitrate int // Min bitrate from frame headers in kbit/s
+	MaxBitrate int // Max bitrate from frame headers in kbit/s
+	AudioSize  int64
+	Audio      time.Duration
+
+	Err error
+}
+
+// probeCheck contains result of comparison of stream parameter with stats
+type probeCheck struct {
+	Name   string
+	Stream string
+	Stats  string
+	Status string
+}
+
+// countWriter counts written bytes
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// probeBitrateTolerance is max relative difference between measured and
+// advertised bitrate of VBR streams
+const probeBitrateTolerance = 0.1
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// probe connects to the stream, measures connection and detects audio parameters
+func probe(mount string) {
+	mount = formatMount(mount)
+	duration, err := timeutil.ParseDuration(options.GetS(OPT_DURATION))
+
+	if err != nil || duration < time.Second {
+		printErrorExit("Probe duration must be at least 1 second")
+	}
+
+	stats, err := client.GetStats()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	source := stats.Sources[mount]
+
+	if source == nil {
+		printErrorExit("No sources found for mount %s", mount)
+	}
+
+	fmtc.TPrintf("{s-}Probing %s for %s…{!}", mount, timeutil.PrettyDuration(duration))
+
+	res, err := probeStream(mount, duration)
+
+	fmtc.TPrint("")
+
+	if err != nil {
+		printErrorExit("Can't connect to %s: %v", mount, err)
+	}
+
+	printProbeResult(mount, res)
+
+	if printProbeChecks(compareProbeResult(res, source)) {
+		os.Exit(1)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes data to underlying writer and counts written bytes
+func (w *countWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// Bitrate returns average bitrate of received audio in kbit/s
+func (r *probeResult) Bitrate() int {
+	if r.Audio <= 0 {
+		return 0
+	}
+
+	return int(float64(r.AudioSize*8) / r.Audio.Seconds() / 1000)
+}
+
+// Throughput returns effective throughput in kbit/s
+func (r *probeResult) Throughput() int {
+	elapsed := r.Elapsed - r.FirstByteTime
+
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return int(float64(r.Received*8) / elapsed.Seconds() / 1000)
+}
+
+// add adds info about audio chunk
+func (r *probeResult) add(chunk *audio.Chunk) {
+	r.Codec, r.SampleRate, r.Channels = chunk.Codec, chunk.SampleRate, chunk.Channels
+
+	// Header pages of Ogg stream don't contain audio
+	if chunk.Duration == 0 {
+		return
+	}
+
+	r.Audio += chunk.Duration
+	r.AudioSize += int64(len(chunk.Data))
+
+	if chunk.Bitrate > 0 {
+		if r.MinBitrate == 0 || chunk.Bitrate < r.MinBitrate {
+			r.MinBitrate = chunk.Bitrate
+		}
+
+		r.MaxBitrate = max(r.MaxBitrate, chunk.Bitrate)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// probeStream reads stream for given duration and collects measurements
+func probeStream(mount string, duration time.Duration) (*probeResult, error) {
+	start := time.Now()
+	conn, err := openStream(mount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &probeResult{
+		ContentType:   conn.ContentType,
+		ConnectTime:   conn.ConnectTime,
+		FirstByteTime: conn.FirstByteTime,
+	}
+
+	pr, pw := io.Pipe()
+	cw := &countWriter{w: pw}
+	done := make(chan struct{})
+
+	go func() {
+		pw.CloseWithError(conn.Read(cw, nil))
+		close(done)
+	}()
+
+	timer := time.AfterFunc(duration, func() { conn.Close() })
+	format := audio.FormatByContentType(conn.ContentType)
+
+	if format == "" {
+		_, err = io.Copy(io.Discard, pr)
+	} else {
+		r, _ := audio.NewReader(pr, format)
+
+		for {
+			var chunk *audio.Chunk
+
+			chunk, err = r.Read()
+
+			if err != nil {
+				break
+			}
+
+			res.add(chunk)
+		}
+	}
+
+	interrupted := timer.Stop()
+
+	conn.Close()
+	pr.Close()
+	<-done
+
+	res.Elapsed = time.Since(start)
+	res.Received = cw.n
+
+	// Stream was interrupted before the end of probe
+	if interrupted {
+		if err == nil || err == io.EOF {
+			err = errors.New("Stream was closed by server")
+		}
+
+		res.Err = err
+	}
+
+	return res, nil
+}
+
+// compareProbeResult compares detected audio parameters with parameters
+// reported by source
+func compareProbeResult(res *probeResult, source *ic.Source) []*probeCheck {
+	var checks []*probeCheck
+
+	info := source.AudioInfo
+
+	if info == nil {
+		info = &ic.AudioInfo{}
+	}
+
+	codec := &probeCheck{Name: "Codec", Stream: res.Codec}
+
+	if source.Info != nil {
+		switch {
+		case source.Info.SubType != "":
+			codec.Stats = source.Info.SubType
+			codec.Status = getProbeStatus(res.Codec != "", strings.EqualFold(res.Codec, codec.Stats))
+		case source.Info.Type != "":
+			codec.Stats = source.Info.Type
+			codec.Status = getProbeStatus(
+				res.Codec != "",
+				audio.FormatByContentType(codec.Stats) == getCodecFormat(res.Codec),
+			)
+		}
+	}
+
+	checks = append(checks, codec)
+
+	bitrate := &probeCheck{Name: "Bitrate"}
+
+	if res.MinBitrate > 0 && res.MinBitrate == res.MaxBitrate {
+		// Bitrate is the same in all headers, so stream must have exactly the
+		// same bitrate as advertised
+		bitrate.Stream = fmt.Sprintf("%d kbit/s", res.MinBitrate)
+		bitrate.Status = getProbeStatus(info.Bitrate > 0, res.MinBitrate == info.Bitrate)
+	} else if res.Bitrate() > 0 {
+		bitrate.Stream = fmt.Sprintf("%d kbit/s (avg)", res.Bitrate())
+		bitrate.Status = getProbeStatus(
+			info.Bitrate > 0,
+			abs(res.Bitrate()-info.Bitrate) <= int(float64(info.Bitrate)*probeBitrateTolerance),
+		)
+	}
+
+	if info.Bitrate > 0 {
+		bitrate.Stats = fmt.Sprintf("%d kbit/s", info.Bitrate)
+	}
+
+	checks = append(checks, bitrate)
+
+	sampleRate := &probeCheck{
+		Name:   "Sample Rate",
+		Status: getProbeStatus(info.SampleRate > 0 && res.SampleRate > 0, res.SampleRate == info.SampleRate),
+	}
+
+	if res.SampleRate > 0 {
+		sampleRate.Stream = fmtutil.PrettyNum(res.SampleRate) + " Hz"
+	}
+
+	if info.SampleRate > 0 {
+		sampleRate.Stats = fmtutil.PrettyNum(info.SampleRate) + " Hz"
+	}
+
+	checks = append(checks, sampleRate)
+
+	channels := &probeCheck{
+		Name:   "Channels",
+		Status: getProbeStatus(info.Channels > 0 && res.Channels > 0, res.Channels == info.Channels),
+	}
+
+	if res.Channels > 0 {
+		channels.Stream = fmtutil.PrettyNum(res.Channels)
+	}
+
+	if info.Channels > 0 {
+		channels.Stats = fmtutil.PrettyNum(info.Channels)
+	}
+
+	checks = append(checks, channels)
+
+	return checks
+}
+
+// printProbeResult prints stream measurements
+func printProbeResult(mount string, res *probeResult) {
+	fmtc.NewLine()
+	showSeparator(false)
+	fmtc.Printfn(" {*y}%s{!}", mount)
+	showSeparator(false)
+	fmtc.Printfn(" {*}%-20s{!} {s}|{!} %s", "Content Type", formatString(res.ContentType))
+	fmtc.Printfn(" {*}%-20s{!} {s}|{!} %s", "Connect Time", timeutil.MiniDuration(res.ConnectTime))
+	fmtc.Printfn(" {*}%-20s{!} {s}|{!} %s", "Time To First Byte", timeutil.MiniDuration(res.FirstByteTime))
+	fmtc.Printfn(
+		" {*}%-20s{!} {s}|{!} %s {s-}(in %s){!}", "Received",
+		fmtutil.PrettySize(res.Received), timeutil.PrettyDuration(res.Elapsed),
+	)
+	fmtc.Printfn(" {*}%-20s{!} {s}|{!} %d kbit/s", "Throughput", res.Throughput())
+
+	if res.Audio > 0 {
+		ratio := res.Audio.Seconds() / (res.Elapsed - res.FirstByteTime).Seconds()
+		color := "{g}"
+
+		if ratio < 1 {
+			color = "{r}"
+		}
+
+		fmtc.Printfn(
+			" {*}%-20s{!} {s}|{!} %s "+color+"(%.2fx realtime){!}", "Audio Received",
+			timeutil.ShortDuration(res.Audio, true), ratio,
+		)
+	}
+
+	if res.Err != nil {
+		fmtc.Printfn(" {*}%-20s{!} {s}|{!} {r}%v{!}", "Stream Error", res.Err)
+	}
+
+	showSeparator(true)
+}
+
+// printProbeChecks prints comparison of detected parameters with stats. Returns
+// true if some parameters don't match.
+func printProbeChecks(checks []*probeCheck) bool {
+	hasProblems := false
+
+	t := table.NewTable("parameter", "stream", "stats", "status")
+	t.SetSizes(20, 20, 20)
+
+	fmtc.NewLine()
+
+	for _, c := range checks {
+		var status string
+
+		switch c.Status {
+		case VERIFY_OK:
+			status = "{g}" + c.Status + "{!}"
+		case VERIFY_MISMATCH:
+			status = "{r}" + c.Status + "{!}"
+			hasProblems = true
+		default:
+			status = "{s-}—{!}"
+		}
+
+		t.Print(c.Name, formatString(c.Stream), formatString(c.Stats), status)
+	}
+
+	t.Separator()
+	fmtc.NewLine()
+
+	return hasProblems
+}
+
+// getProbeStatus returns status of check
+func getProbeStatus(known, equal bool) string {
+	switch {
+	case !known:
+		return ""
+	case equal:
+		return VERIFY_OK
+	}
+
+	return VERIFY_MISMATCH
+}
+
+// getCodecFormat returns audio format used for given codec
+func getCodecFormat(codec string) string {
+	switch codec {
+	case "mp1", "mp2", "mp3":
+		return audio.FORMAT_MP3
+	case "aac":
+		return audio.FORMAT_AAC
+	case "vorbis", "opus", "flac":
+		return audio.FORMAT_OGG
+	}
+
+	return ""
+}
+
+// abs returns absolute value of given number
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdProbe shows help for "probe" command
+func helpCmdProbe() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Connects to the mountpoint as a listener, reads the stream for some time and")
+	fmtc.Println("  measures connect time, time to first byte and effective throughput. Codec,")
+	fmtc.Println("  sample rate, channels and bitrate are detected from MP3 frame headers, AAC ADTS")
+	fmtc.Println("  headers or Ogg pages and compared with audio info advertised by the source.")
+	fmtc.Println("  Command exits with error code if detected parameters don't match stats.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_PROBE)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-10s{!} - Probe duration {s-}(default: 10s){!}", options.F(OPT_DURATION))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /source1.mp3", APP, CMD_PROBE)
+	fmtc.Printfn("  %s %s %s 1m /source1.ogg", APP, CMD_PROBE, options.F(OPT_DURATION))
+	fmtc.NewLine()
+}
@@ -0,0 +1,289 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal/tty"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+	"github.com/essentialkaos/icecli/cli/source"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pushLead is duration of audio sent ahead of real time to fill server buffer
+const pushLead = 2 * time.Second
+
+// rawChunkSize is size of chunk for streams with unknown format
+const rawChunkSize = 4096
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rawReader reads stream with unknown format using fixed bitrate for pacing
+type rawReader struct {
+	r       io.Reader
+	bitrate int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// push streams given file or stdin to the mount point as a source client
+func push(mount, file string) {
+	mount = formatMount(mount)
+
+	input, name, err := openPushInput(file)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	defer input.Close()
+
+	contentType, reader, err := getPushReader(input, file)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	chunk, err := reader.Read()
+
+	if err != nil {
+		printErrorExit("Can't read audio data from %s: %v", name, err)
+	}
+
+	conn, err := source.Connect(&source.Config{
+		URL:         getHostURL(),
+		Mount:       mount,
+		User:        options.GetS(OPT_SOURCE_USER),
+		Password:    options.GetS(OPT_SOURCE_PASS),
+		ContentType: contentType,
+		Timeout:     streamTimeout,
+		Info:        getSourceInfo(chunk),
+	})
+
+	if err != nil {
+		printErrorExit("Can't connect to %s: %v", mount, err)
+	}
+
+	defer conn.Close()
+
+	fmtc.Printfn(
+		"{s-}Streaming %s to %s {s}(%s, %s){!}{s-}…{!}",
+		name, mount, contentType, conn.Method,
+	)
+
+	sent, dur, err := streamAudio(conn, reader, chunk)
+
+	if err != nil {
+		printErrorExit("Streaming error: %v", err)
+	}
+
+	fmtc.Printfn(
+		"{g}Done, sent %s of audio {s}(%s){!}",
+		timeutil.PrettyDuration(dur), fmtutil.PrettySize(sent),
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads next chunk of raw stream
+func (r *rawReader) Read() (*audio.Chunk, error) {
+	buf := make([]byte, rawChunkSize)
+	n, err := io.ReadFull(r.r, buf)
+
+	if n == 0 {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+
+		return nil, err
+	}
+
+	return &audio.Chunk{
+		Data:     buf[:n],
+		Duration: time.Duration(n*8) * time.Second / time.Duration(r.bitrate*1000),
+		Bitrate:  r.bitrate,
+	}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// openPushInput opens file or stdin for streaming
+func openPushInput(file string) (io.ReadCloser, string, error) {
+	if file == "-" {
+		return os.Stdin, "stdin", nil
+	}
+
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("Can't open file: %w", err)
+	}
+
+	return fd, file, nil
+}
+
+// getPushReader returns content type and audio reader for given input
+func getPushReader(r io.Reader, file string) (string, audio.Reader, error) {
+	var format string
+
+	contentType := options.GetS(OPT_CONTENT_TYPE)
+
+	if contentType != "" {
+		format = audio.FormatByContentType(contentType)
+	} else if file != "-" {
+		format = audio.FormatByExt(file)
+		contentType = audio.ContentType(format)
+	}
+
+	switch {
+	case contentType == "":
+		return "", nil, fmt.Errorf("Can't detect stream format, use %s to define it", options.F(OPT_CONTENT_TYPE))
+	case format == "" && !options.Has(OPT_BITRATE):
+		return "", nil, fmt.Errorf(
+			"Can't detect bitrate for %s stream, use %s to define it",
+			contentType, options.F(OPT_BITRATE),
+		)
+	case format == "":
+		return contentType, &rawReader{r: r, bitrate: options.GetI(OPT_BITRATE)}, nil
+	}
+
+	reader, err := audio.NewReader(r, format)
+
+	return contentType, reader, err
+}
+
+// getSourceInfo returns stream info for ice-* headers
+func getSourceInfo(chunk *audio.Chunk) *source.Info {
+	info := &source.Info{
+		Name:        options.GetS(OPT_STREAM_NAME),
+		Description: options.GetS(OPT_STREAM_DESC),
+		Genre:       options.GetS(OPT_STREAM_GENRE),
+		URL:         options.GetS(OPT_STREAM_URL),
+		Public:      options.GetB(OPT_PUBLIC),
+		AudioInfo:   map[string]string{},
+	}
+
+	bitrate := chunk.Bitrate
+
+	if options.Has(OPT_BITRATE) {
+		bitrate = options.GetI(OPT_BITRATE)
+	}
+
+	if bitrate > 0 {
+		info.AudioInfo["bitrate"] = strconv.Itoa(bitrate)
+	}
+
+	if chunk.SampleRate > 0 {
+		info.AudioInfo["samplerate"] = strconv.Itoa(chunk.SampleRate)
+	}
+
+	if chunk.Channels > 0 {
+		info.AudioInfo["channels"] = strconv.Itoa(chunk.Channels)
+	}
+
+	return info
+}
+
+// streamAudio sends audio chunks to the server with real-time pacing.
+// Returns number of sent bytes and duration of sent audio.
+func streamAudio(w io.Writer, reader audio.Reader, chunk *audio.Chunk) (int64, time.Duration, error) {
+	var sent int64
+	var lastProgress time.Time
+
+	pacer := &audio.Pacer{Lead: pushLead}
+	bitrate := options.GetI(OPT_BITRATE)
+	showProgress := tty.IsTTY()
+
+	for {
+		duration := chunk.Duration
+
+		if duration == 0 && bitrate > 0 {
+			duration = time.Duration(len(chunk.Data)*8) * time.Second / time.Duration(bitrate*1000)
+		}
+
+		pacer.Wait(duration)
+
+		_, err := w.Write(chunk.Data)
+
+		if err != nil {
+			return sent, pacer.Sent(), err
+		}
+
+		sent += int64(len(chunk.Data))
+
+		if showProgress && time.Since(lastProgress) >= time.Second {
+			lastProgress = time.Now()
+			fmtc.TPrintf(
+				"{s}Sent %s {s-}(%s){!}",
+				timeutil.ShortDuration(pacer.Sent()), fmtutil.PrettySize(sent),
+			)
+		}
+
+		chunk, err = reader.Read()
+
+		if err != nil {
+			if showProgress {
+				fmtc.TPrint("")
+			}
+
+			if errors.Is(err, io.EOF) {
+				return sent, pacer.Sent(), nil
+			}
+
+			return sent, pacer.Sent(), err
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdPush shows help for "push" command
+func helpCmdPush() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Streams file or data from standard input to the mountpoint as a source client.")
+	fmtc.Println("  Command uses HTTP PUT method and falls back to legacy SOURCE method if server")
+	fmtc.Println("  doesn't support PUT. Data is sent with real-time speed calculated from MP3/AAC")
+	fmtc.Println("  frames or Ogg pages. For other formats, bitrate must be defined with option.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!} {g}file{!}", APP, CMD_PUSH)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.Println("  {g}file{!}  - Path to audio file or {y}-{!} for standard input")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-20s{!} - Source user {s-}(default: source){!}", options.F(OPT_SOURCE_USER))
+	fmtc.Printfn("  {g}%-20s{!} - Source password {s-}(default: hackme){!}", options.F(OPT_SOURCE_PASS))
+	fmtc.Printfn("  {g}%-20s{!} - Stream content type {s-}(required for stdin){!}", options.F(OPT_CONTENT_TYPE))
+	fmtc.Printfn("  {g}%-20s{!} - Stream bitrate in kbit/s", options.F(OPT_BITRATE))
+	fmtc.Printfn("  {g}%-20s{!} - Stream name", options.F(OPT_STREAM_NAME))
+	fmtc.Printfn("  {g}%-20s{!} - Stream description", options.F(OPT_STREAM_DESC))
+	fmtc.Printfn("  {g}%-20s{!} - Stream genre", options.F(OPT_STREAM_GENRE))
+	fmtc.Printfn("  {g}%-20s{!} - Stream website URL", options.F(OPT_STREAM_URL))
+	fmtc.Printfn("  {g}%-20s{!} - List stream in public directories", options.F(OPT_PUBLIC))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /live.mp3 track.mp3", APP, CMD_PUSH)
+	fmtc.Printfn("  %s %s %s 'My Radio' %s secret /live.ogg show.ogg", APP, CMD_PUSH, options.F(OPT_STREAM_NAME), options.F(OPT_SOURCE_PASS))
+	fmtc.Printfn("  ffmpeg -i input.flac -f mp3 - | %s %s %s audio/mpeg /live.mp3 -", APP, CMD_PUSH, options.F(OPT_CONTENT_TYPE))
+	fmtc.NewLine()
+}
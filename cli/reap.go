@@ -0,0 +1,170 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// reaper kills clients with lag above threshold
+type reaper struct {
+	Mount    string
+	MaxLag   int
+	Grace    time.Duration
+	Interval time.Duration
+	DryRun   bool
+
+	slowSince map[string]time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startReaper starts slow listeners reaper
+func startReaper(mount string) {
+	r := &reaper{
+		MaxLag:    int(fmtutil.ParseSize(options.GetS(OPT_MAX_LAG))),
+		DryRun:    options.GetB(OPT_DRY_RUN),
+		slowSince: map[string]time.Time{},
+	}
+
+	if mount != "" {
+		r.Mount = formatMount(mount)
+	}
+
+	if r.MaxLag <= 0 {
+		printErrorExit("Can't parse %s value %q", options.F(OPT_MAX_LAG), options.GetS(OPT_MAX_LAG))
+	}
+
+	var err error
+
+	r.Grace, err = timeutil.ParseDuration(options.GetS(OPT_GRACE))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_GRACE), err)
+	}
+
+	r.Interval, err = parseInterval()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	log.Info(
+		"Reaper started (mount: %s, max lag: %s, grace period: %s, interval: %s, dry run: %t)",
+		formatMountFilter(r.Mount), fmtutil.PrettySize(r.MaxLag),
+		timeutil.PrettyDuration(r.Grace), timeutil.PrettyDuration(r.Interval), r.DryRun,
+	)
+
+	for {
+		r.Check()
+		time.Sleep(r.Interval)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Check checks clients lag and kills clients which are slow for too long
+func (r *reaper) Check() {
+	clients, err := listMountsClients(r.Mount)
+
+	if err != nil {
+		log.Error("Can't get list of clients: %v", err)
+		return
+	}
+
+	now := time.Now()
+	slowSince := map[string]time.Time{}
+
+	for _, c := range clients {
+		if c.Lag <= r.MaxLag {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%d", c.Mount, c.ID)
+		since, ok := r.slowSince[key]
+
+		if !ok {
+			since = now
+		}
+
+		if now.Sub(since) < r.Grace {
+			slowSince[key] = since
+			continue
+		}
+
+		if r.DryRun {
+			log.Info(
+				"[DRY RUN] Client %d (%s) on %s has lag %s for %s and would be killed",
+				c.ID, c.IP, c.Mount, fmtutil.PrettySize(c.Lag),
+				timeutil.PrettyDuration(now.Sub(since)),
+			)
+			slowSince[key] = since
+			continue
+		}
+
+		err = client.KillClient(c.Mount, c.ID)
+
+		if err != nil {
+			log.Error("Can't kill client %d (%s) on %s: %v", c.ID, c.IP, c.Mount, err)
+			slowSince[key] = since
+			continue
+		}
+
+		log.Info(
+			"Client %d (%s) killed on %s: lag %s for %s (user-agent: %q)",
+			c.ID, c.IP, c.Mount, fmtutil.PrettySize(c.Lag),
+			timeutil.PrettyDuration(now.Sub(since)), c.UserAgent,
+		)
+	}
+
+	r.slowSince = slowSince
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdReap shows help for "reap" command
+func helpCmdReap() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Periodically checks clients of all (or given) mountpoints and kills clients")
+	fmtc.Println("  whose lag stays above the threshold for longer than the grace period. Every")
+	fmtc.Println("  eviction is written to the log.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_REAP)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(optional, with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-13s{!} - Max client lag {s-}(default: 1MB){!}", options.F(OPT_MAX_LAG))
+	fmtc.Printfn("  {g}%-13s{!} - Grace period {s-}(default: 30s){!}", options.F(OPT_GRACE))
+	fmtc.Printfn("  {g}%-13s{!} - Check interval {s-}(default: 5s){!}", options.F(OPT_INTERVAL))
+	fmtc.Printfn("  {g}%-13s{!} - Only log clients which would be killed", options.F(OPT_DRY_RUN))
+	fmtc.Printfn("  {g}%-13s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s", APP, CMD_REAP)
+	fmtc.Printfn("  %s %s %s 512KB %s 1m /source1.ogg", APP, CMD_REAP, options.F(OPT_MAX_LAG), options.F(OPT_GRACE))
+	fmtc.NewLine()
+}
@@ -0,0 +1,189 @@
+package record
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+	"github.com/essentialkaos/icecli/cli/tags"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// oggState contains state of Ogg stream splitter
+type oggState struct {
+	buf       []byte   // Incomplete page
+	headers   [][]byte // Header pages of current logical stream
+	inHeaders bool     // Header pages of new logical stream are being collected
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	// oggHeaderSize is size of Ogg page header without lacing values
+	oggHeaderSize = 27
+
+	// noGranule is granule position of page without finished packets
+	noGranule = ^uint64(0)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeOgg splits Ogg stream data to pages and writes them. Every file starts
+// with header pages of logical stream, so it can be played separately.
+func (r *Recorder) writeOgg(data []byte) error {
+	s := r.ogg
+	s.buf = append(s.buf, data...)
+
+	for {
+		start := bytes.Index(s.buf, []byte("OggS"))
+
+		if start < 0 {
+			// Keep last bytes, which may contain the beginning of capture pattern
+			s.buf = s.buf[max(0, len(s.buf)-3):]
+			return nil
+		}
+
+		s.buf = s.buf[start:]
+		size := oggPageSize(s.buf)
+
+		if size == 0 || len(s.buf) < size {
+			return nil
+		}
+
+		page := bytes.Clone(s.buf[:size])
+		s.buf = s.buf[size:]
+
+		err := r.writeOggPage(page)
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeOggPage writes Ogg page
+func (r *Recorder) writeOggPage(page []byte) error {
+	s := r.ogg
+	flags := page[5]
+	granule := binary.LittleEndian.Uint64(page[6:14])
+
+	if flags&stream.OGG_FLAG_BOS != 0 {
+		if !s.inHeaders {
+			s.headers, s.inHeaders = nil, true
+		}
+
+		s.headers = append(s.headers, page)
+
+		return nil
+	}
+
+	if s.inHeaders && (granule == 0 || granule == noGranule) {
+		s.headers = append(s.headers, page)
+		return nil
+	}
+
+	if s.inHeaders {
+		// Header pages of the new logical stream are complete
+		s.inHeaders = false
+
+		err := r.startOggStream()
+
+		if err != nil {
+			return err
+		}
+	} else if r.fd == nil {
+		// File was closed by rotation timer
+		err := r.writeOggHeaders()
+
+		if err != nil {
+			return err
+		}
+	} else if r.needSplit() {
+		err := r.rotateOgg()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.write(page)
+}
+
+// startOggStream reads metadata from comments of the new logical stream and
+// writes header pages to the current or new file
+func (r *Recorder) startOggStream() error {
+	t, err := tags.ReadVorbis(bytes.NewReader(bytes.Join(r.ogg.headers, nil)))
+
+	if err == nil {
+		if r.artist != t.Artist || r.title != t.Title {
+			r.split = r.split || (r.config.SplitMeta && (r.artist != "" || r.title != ""))
+			r.artist, r.title = t.Artist, t.Title
+		}
+	}
+
+	if r.fd != nil && r.needSplit() {
+		err = r.close()
+
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrWrite, err)
+		}
+	}
+
+	return r.writeOggHeaders()
+}
+
+// rotateOgg starts new file with header pages of current logical stream
+func (r *Recorder) rotateOgg() error {
+	err := r.close()
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	return r.writeOggHeaders()
+}
+
+// writeOggHeaders writes header pages of current logical stream
+func (r *Recorder) writeOggHeaders() error {
+	for _, page := range r.ogg.headers {
+		err := r.write(page)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// oggPageSize returns size of page or 0 if page header is incomplete
+func oggPageSize(data []byte) int {
+	if len(data) < oggHeaderSize {
+		return 0
+	}
+
+	segments := int(data[26])
+
+	if len(data) < oggHeaderSize+segments {
+		return 0
+	}
+
+	size := oggHeaderSize + segments
+
+	for _, l := range data[oggHeaderSize : oggHeaderSize+segments] {
+		size += int(l)
+	}
+
+	return size
+}
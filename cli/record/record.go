@@ -0,0 +1,418 @@
+package record
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains recorder configuration
+type Config struct {
+	Dir       string        // Output directory
+	Template  string        // File name template
+	Mount     string        // Mount name (used in file names)
+	Format    string        // Audio format (mp3/aac/ogg or empty for unknown)
+	SplitTime time.Duration // Max file duration
+	SplitSize int64         // Max file size
+	SplitMeta bool          // Start new file on metadata change
+	Align     bool          // Align file boundaries to multiples of split time
+}
+
+// Recorder writes stream data to files with rotation
+type Recorder struct {
+	// OnOpen is called when a new file is created
+	OnOpen func(file string)
+
+	// OnClose is called when file is closed
+	OnClose func(file string, size int64, duration time.Duration)
+
+	config *Config
+	mu     sync.Mutex
+
+	fd        *os.File
+	file      string
+	size      int64
+	started   time.Time
+	deadline  time.Time
+	lastWrite time.Time
+
+	artist string
+	title  string
+	split  bool
+
+	ogg *oggState
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrWrite is returned if data can't be written to the file
+var ErrWrite = errors.New("Can't write data to file")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new recorder
+func New(config *Config) (*Recorder, error) {
+	switch {
+	case config == nil:
+		return nil, errors.New("Configuration is nil")
+	case config.Template == "":
+		return nil, errors.New("File name template is empty")
+	}
+
+	r := &Recorder{config: config}
+
+	if config.Format == audio.FORMAT_OGG {
+		r.ogg = &oggState{}
+	}
+
+	return r, nil
+}
+
+// MountName returns mount name which can be used as a part of file path
+func MountName(mount string) string {
+	mount = strings.TrimLeft(mount, "/")
+	mount = strings.TrimSuffix(mount, path.Ext(mount))
+
+	return sanitizeName(mount)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes stream data. New file is started on frame (or page) boundary if
+// one of split conditions is met.
+func (r *Recorder) Write(data []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+
+	if r.ogg != nil {
+		err = r.writeOgg(data)
+	} else {
+		err = r.writeData(data)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// SetMeta sets current track info. If current file was created before the
+// first metadata block, file is renamed. Otherwise, new file is started if
+// splitting on metadata change is enabled.
+func (r *Recorder) SetMeta(artist, title string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if artist == r.artist && title == r.title {
+		return nil
+	}
+
+	hadMeta := r.artist != "" || r.title != ""
+	r.artist, r.title = artist, title
+
+	switch {
+	case r.fd != nil && !hadMeta:
+		return r.rename()
+	case hadMeta && r.config.SplitMeta:
+		r.split = true
+	}
+
+	return nil
+}
+
+// Split starts a new file on the next frame (or page) boundary
+func (r *Recorder) Split() {
+	r.mu.Lock()
+	r.split = true
+	r.mu.Unlock()
+}
+
+// Reset discards incomplete data and starts a new file. Must be called if
+// stream was reconnected.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.split = true
+
+	if r.ogg != nil {
+		r.ogg.buf = nil
+	}
+}
+
+// Close closes current file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.close()
+}
+
+// Rotate closes current file if its max duration is reached, but there was no
+// data since that moment. Must be called periodically, so files are rotated in
+// time even if stream is stalled or disconnected.
+func (r *Recorder) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fd == nil || r.deadline.IsZero() ||
+		time.Now().Before(r.deadline) || !r.lastWrite.Before(r.deadline) {
+		return nil
+	}
+
+	err := r.close()
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// close closes current file
+func (r *Recorder) close() error {
+	if r.fd == nil {
+		return nil
+	}
+
+	err := r.fd.Close()
+
+	if r.OnClose != nil {
+		r.OnClose(r.file, r.size, time.Since(r.started))
+	}
+
+	r.fd, r.file, r.size = nil, "", 0
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeData writes data of MPEG audio, AAC or unknown stream
+func (r *Recorder) writeData(data []byte) error {
+	if r.fd != nil && r.needSplit() {
+		offset := 0
+
+		if r.config.Format == audio.FORMAT_MP3 || r.config.Format == audio.FORMAT_AAC {
+			offset = audio.FindFrame(data)
+		}
+
+		// There is no frame boundary in this chunk, wait for the next one
+		if offset < 0 {
+			return r.write(data)
+		}
+
+		err := r.write(data[:offset])
+
+		if err != nil {
+			return err
+		}
+
+		err = r.close()
+
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrWrite, err)
+		}
+
+		data = data[offset:]
+	}
+
+	return r.write(data)
+}
+
+// write writes data to the current file. New file is created if there is no
+// opened file.
+func (r *Recorder) write(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	if r.fd == nil {
+		err := r.open()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := r.fd.Write(data)
+	r.size += int64(n)
+	r.lastWrite = time.Now()
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	return nil
+}
+
+// needSplit returns true if a new file must be started
+func (r *Recorder) needSplit() bool {
+	switch {
+	case r.split:
+		return true
+	case !r.deadline.IsZero() && !time.Now().Before(r.deadline):
+		return true
+	case r.config.SplitSize > 0 && r.size >= r.config.SplitSize:
+		return true
+	}
+
+	return false
+}
+
+// open creates new file
+func (r *Recorder) open() error {
+	now := time.Now()
+	file, err := r.genFileName(now)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	fd, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	r.fd, r.file, r.size, r.started, r.split = fd, file, 0, now, false
+
+	switch {
+	case r.config.SplitTime <= 0:
+		r.deadline = time.Time{}
+	case r.config.Align:
+		r.deadline = now.Truncate(r.config.SplitTime).Add(r.config.SplitTime)
+	default:
+		r.deadline = now.Add(r.config.SplitTime)
+	}
+
+	if r.OnOpen != nil {
+		r.OnOpen(file)
+	}
+
+	return nil
+}
+
+// rename renames current file using current metadata
+func (r *Recorder) rename() error {
+	file, err := r.genFileName(r.started)
+
+	if err != nil || file == r.file {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+
+	if err == nil {
+		err = os.Rename(r.file, file)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: Can't rename file: %v", ErrWrite, err)
+	}
+
+	r.file = file
+
+	if r.OnOpen != nil {
+		r.OnOpen(file)
+	}
+
+	return nil
+}
+
+// genFileName generates unique name of file using template
+func (r *Recorder) genFileName(t time.Time) (string, error) {
+	name := timeutil.Format(t, r.config.Template)
+	name = strings.NewReplacer(
+		"{mount}", MountName(r.config.Mount),
+		"{artist}", sanitizeName(defaultValue(r.artist, "Unknown")),
+		"{title}", sanitizeName(defaultValue(r.title, "Unknown")),
+	).Replace(name)
+
+	base := filepath.Join(r.config.Dir, name)
+	ext := getFileExt(r.config.Format)
+
+	for i := 1; i < 1000; i++ {
+		file := base + ext
+
+		if i > 1 {
+			file = fmt.Sprintf("%s_%d%s", base, i, ext)
+		}
+
+		if file == r.file {
+			return file, nil
+		}
+
+		_, err := os.Stat(file)
+
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: Can't find unique name for file %s", ErrWrite, base+ext)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getFileExt returns file extension for given audio format
+func getFileExt(format string) string {
+	if format == "" {
+		return ".bin"
+	}
+
+	return "." + format
+}
+
+// sanitizeName removes symbols which can't be used in file names
+func sanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r < 32, r == '/', r == '\\', r == ':', r == '*', r == '?',
+			r == '"', r == '<', r == '>', r == '|':
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	return strings.Trim(strings.TrimSpace(name), ".")
+}
+
+// defaultValue returns default value if value is empty
+func defaultValue(value, defValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return defValue
+	}
+
+	return value
+}
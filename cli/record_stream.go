@@ -0,0 +1,281 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+	"github.com/essentialkaos/icecli/cli/record"
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// recordTemplate is default template for names of recorded files
+const recordTemplate = "{mount}/%Y-%m-%d_%H-%M-%S"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// streamReconnectDelay is min delay between listener reconnection attempts
+var streamReconnectDelay = time.Second
+
+// streamMaxReconnectDelay is max delay between listener reconnection attempts
+var streamMaxReconnectDelay = 30 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// recordStream records stream of given mount point to files
+func recordStream(mount string) {
+	mount = formatMount(mount)
+	config, err := getRecordConfig(mount)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	log.Info("Recording %s to %s", mount, config.Dir)
+
+	err = recordMount(mount, config)
+
+	log.Crit(err.Error())
+	printErrorExit(err.Error())
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// recordMount records stream of given mount point using given configuration.
+// Connection is restored automatically, function returns only on file
+// writing error.
+func recordMount(mount string, config *record.Config) error {
+	var rec *record.Recorder
+	var disconnected time.Time
+
+	delay := streamReconnectDelay
+	stop := make(chan struct{})
+
+	defer close(stop)
+
+	for {
+		conn, err := openStream(mount)
+
+		if err != nil {
+			log.Error("Can't connect to %s: %v", mount, err)
+
+			if disconnected.IsZero() {
+				disconnected = time.Now()
+			}
+
+			time.Sleep(delay)
+			delay = min(delay*2, streamMaxReconnectDelay)
+
+			continue
+		}
+
+		if rec == nil {
+			rec, err = newStreamRecorder(config, conn)
+
+			if err != nil {
+				conn.Close()
+				return err
+			}
+
+			if config.SplitTime > 0 {
+				go rotateRecords(mount, rec, stop)
+			}
+		}
+
+		if !disconnected.IsZero() {
+			log.Warn(
+				"Reconnected to %s, gap in recording: %s (%s – %s)",
+				mount, timeutil.PrettyDuration(time.Since(disconnected)),
+				timeutil.Format(disconnected, "%H:%M:%S"),
+				timeutil.Format(time.Now(), "%H:%M:%S"),
+			)
+
+			rec.Reset()
+		}
+
+		delay = streamReconnectDelay
+
+		err = conn.Read(rec, func(meta *stream.Meta) {
+			if conn.Format == stream.FORMAT_ICY {
+				artist, title := splitStreamTitle(meta.StreamTitle)
+				err := rec.SetMeta(artist, title)
+
+				if err != nil {
+					log.Error("Can't update track info for %s: %v", mount, err)
+				}
+			}
+		})
+
+		conn.Close()
+		disconnected = time.Now()
+
+		if errors.Is(err, record.ErrWrite) {
+			rec.Close()
+			return err
+		}
+
+		log.Error("Stream %s disconnected: %v", mount, err)
+
+		time.Sleep(delay)
+	}
+}
+
+// rotateRecords periodically rotates files of recorder, so files are closed in
+// time even if stream is stalled or disconnected
+func rotateRecords(mount string, rec *record.Recorder, stop chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := rec.Rotate()
+
+			if err != nil {
+				log.Error("Can't rotate file for %s: %v", mount, err)
+			}
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getRecordConfig returns recorder configuration from options
+func getRecordConfig(mount string) (*record.Config, error) {
+	config := &record.Config{
+		Dir:       options.GetS(OPT_OUTPUT),
+		Template:  options.GetS(OPT_TEMPLATE),
+		Mount:     mount,
+		SplitMeta: options.GetB(OPT_SPLIT_META),
+	}
+
+	if config.Template == "" {
+		config.Template = recordTemplate
+
+		if config.SplitMeta {
+			config.Template += "_{artist} - {title}"
+		}
+	}
+
+	if options.Has(OPT_SPLIT_TIME) {
+		splitTime, err := timeutil.ParseDuration(options.GetS(OPT_SPLIT_TIME))
+
+		if err != nil || splitTime < time.Minute {
+			return nil, errors.New("Split time must be at least 1 minute")
+		}
+
+		config.SplitTime = splitTime
+	}
+
+	if options.Has(OPT_SPLIT_SIZE) {
+		config.SplitSize = int64(fmtutil.ParseSize(options.GetS(OPT_SPLIT_SIZE)))
+
+		if config.SplitSize < 1024*1024 {
+			return nil, errors.New("Split size must be at least 1MB")
+		}
+	}
+
+	err := os.MkdirAll(config.Dir, 0755)
+
+	if err != nil {
+		return nil, errors.New("Can't create output directory: " + err.Error())
+	}
+
+	return config, nil
+}
+
+// newStreamRecorder creates recorder for the stream
+func newStreamRecorder(config *record.Config, conn *stream.Conn) (*record.Recorder, error) {
+	config.Format = audio.FormatByContentType(conn.ContentType)
+
+	rec, err := record.New(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rec.OnOpen = func(file string) {
+		log.Info("Recording to %s", file)
+	}
+
+	rec.OnClose = func(file string, size int64, duration time.Duration) {
+		log.Info(
+			"File %s saved (%s, %s)", file,
+			fmtutil.PrettySize(size), timeutil.ShortDuration(duration),
+		)
+	}
+
+	return rec, nil
+}
+
+// splitStreamTitle splits ICY stream title to artist and title
+func splitStreamTitle(streamTitle string) (string, string) {
+	artist, title, ok := strings.Cut(streamTitle, " - ")
+
+	if !ok {
+		return "", strings.TrimSpace(streamTitle)
+	}
+
+	return strings.TrimSpace(artist), strings.TrimSpace(title)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdRecordStream shows help for "record-stream" command
+func helpCmdRecordStream() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Connects to the mountpoint as a listener and records the stream to files.")
+	fmtc.Println("  Files can be rotated by time or size, or split on every change of in-band")
+	fmtc.Println("  metadata. Files are split on MP3/AAC frame or Ogg page boundary, and every")
+	fmtc.Println("  Ogg file starts with stream headers. Command reconnects automatically and")
+	fmtc.Println("  logs every gap in recording.")
+	fmtc.NewLine()
+	fmtc.Println("  File name template supports date sequences {s-}(%Y, %m, %d, %H, %M, %S…){!} and")
+	fmtc.Println("  {y}{mount}{!}, {y}{artist}{!} and {y}{title}{!} placeholders. Extension is added automatically.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_RECORD_STREAM)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-13s{!} - Output directory {s-}(default: current directory){!}", options.F(OPT_OUTPUT))
+	fmtc.Printfn("  {g}%-13s{!} - File name template {s-}(default: %s){!}", options.F(OPT_TEMPLATE), recordTemplate)
+	fmtc.Printfn("  {g}%-13s{!} - Max file duration {s-}(e.g. 1h){!}", options.F(OPT_SPLIT_TIME))
+	fmtc.Printfn("  {g}%-13s{!} - Max file size {s-}(e.g. 100MB){!}", options.F(OPT_SPLIT_SIZE))
+	fmtc.Printfn("  {g}%-13s{!} - Start new file on metadata change", options.F(OPT_SPLIT_META))
+	fmtc.Printfn("  {g}%-13s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s %s 1h %s /srv/shows /source1.mp3", APP, CMD_RECORD_STREAM, options.F(OPT_SPLIT_TIME), options.F(OPT_OUTPUT))
+	fmtc.Printfn("  %s %s %s /source1.ogg", APP, CMD_RECORD_STREAM, options.F(OPT_SPLIT_META))
+	fmtc.Printfn("  %s %s %s '%%Y/%%m/%%d/{artist} - {title}' %s /source1.mp3", APP, CMD_RECORD_STREAM, options.F(OPT_TEMPLATE), options.F(OPT_SPLIT_META))
+	fmtc.NewLine()
+}
@@ -0,0 +1,468 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/jsonutil"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// programmeSchedule contains weekly programme grid, one-off overrides and
+// holidays
+type programmeSchedule struct {
+	Timezone  string              `json:"timezone"`
+	Shows     []*scheduleShow     `json:"shows"`
+	Overrides []*scheduleOverride `json:"overrides"`
+	Holidays  []*scheduleHoliday  `json:"holidays"`
+
+	location *time.Location
+}
+
+// scheduleProgramme contains info about show
+type scheduleProgramme struct {
+	Name        string `json:"name"`
+	Host        string `json:"host"`
+	Description string `json:"description"`
+}
+
+// scheduleShow is show in weekly grid
+type scheduleShow struct {
+	scheduleProgramme
+
+	Days  []string `json:"days"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+
+	weekdays []time.Weekday
+	start    time.Duration
+	end      time.Duration
+}
+
+// scheduleOverride is one-off show which replaces weekly grid
+type scheduleOverride struct {
+	scheduleProgramme
+
+	Start string `json:"start"`
+	End   string `json:"end"`
+
+	start time.Time
+	end   time.Time
+}
+
+// scheduleHoliday is day without weekly grid shows
+type scheduleHoliday struct {
+	scheduleProgramme
+
+	Date string `json:"date"`
+
+	date time.Time
+}
+
+// scheduleSlot is programme which is on air at some moment
+type scheduleSlot struct {
+	Programme *scheduleProgramme
+	Start     time.Time
+}
+
+// scheduler updates metadata on programme boundaries
+type scheduler struct {
+	File     string
+	Mounts   []string
+	Interval time.Duration
+	DryRun   bool
+
+	schedule  *programmeSchedule
+	modTime   time.Time
+	lastSlots map[string]string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// scheduleWeekdays is map with weekday names
+var scheduleWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday,
+	"wed": time.Wednesday, "thu": time.Thursday, "fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startScheduler starts scheduled metadata updates for given mount points
+func startScheduler(file string, mounts []string) {
+	s := &scheduler{
+		File:      file,
+		DryRun:    options.GetB(OPT_DRY_RUN),
+		lastSlots: map[string]string{},
+	}
+
+	for _, m := range mounts {
+		s.Mounts = append(s.Mounts, formatMount(m))
+	}
+
+	var err error
+
+	s.Interval, err = parseInterval()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = s.Reload()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	log.Info(
+		"Scheduler started (schedule: %s, mounts: %s, interval: %s, dry run: %t)",
+		s.File, strings.Join(s.Mounts, ", "), timeutil.PrettyDuration(s.Interval), s.DryRun,
+	)
+
+	for {
+		s.Check()
+		time.Sleep(s.Interval)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Reload reads schedule from file if it was changed
+func (s *scheduler) Reload() error {
+	modTime, err := fsutil.GetMTime(s.File)
+
+	if err != nil {
+		return fmt.Errorf("Can't read schedule: %w", err)
+	}
+
+	if s.schedule != nil && modTime.Equal(s.modTime) {
+		return nil
+	}
+
+	schedule, err := readSchedule(s.File)
+
+	if err != nil {
+		return err
+	}
+
+	s.schedule, s.modTime = schedule, modTime
+
+	return nil
+}
+
+// Check updates metadata if programme was changed
+func (s *scheduler) Check() {
+	err := s.Reload()
+
+	if err != nil {
+		log.Error("Can't reload schedule, previous version will be used: %v", err)
+	}
+
+	slot := s.schedule.Find(time.Now())
+
+	if slot == nil {
+		return
+	}
+
+	key := slot.Start.Format(time.RFC3339) + "|" + slot.Programme.Name
+	meta := slot.Programme.ToMeta()
+
+	for _, mount := range s.Mounts {
+		if s.lastSlots[mount] == key {
+			continue
+		}
+
+		if s.DryRun {
+			log.Info("[DRY RUN] Metadata for %s would be updated: %s", mount, formatTrackMeta(meta))
+			s.lastSlots[mount] = key
+			continue
+		}
+
+		err = sendMeta(mount, meta)
+
+		if err != nil {
+			log.Error("Can't update metadata for %s: %v", mount, err)
+			continue
+		}
+
+		s.lastSlots[mount] = key
+
+		log.Info("Metadata updated for %s: %s", mount, formatTrackMeta(meta))
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Find returns programme which is on air at given moment. Overrides have the
+// highest priority, weekly grid is ignored on holidays.
+func (s *programmeSchedule) Find(t time.Time) *scheduleSlot {
+	t = t.In(s.location)
+
+	for _, o := range s.Overrides {
+		if !t.Before(o.start) && t.Before(o.end) {
+			return &scheduleSlot{&o.scheduleProgramme, o.start}
+		}
+	}
+
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+
+	if h := s.findHoliday(day); h != nil && h.Name != "" {
+		return &scheduleSlot{&h.scheduleProgramme, day}
+	}
+
+	// Check previous day for shows which end after midnight
+	for _, d := range []time.Time{day, day.AddDate(0, 0, -1)} {
+		if s.findHoliday(d) != nil {
+			continue
+		}
+
+		for _, show := range s.Shows {
+			if !show.HasWeekday(d.Weekday()) {
+				continue
+			}
+
+			start, end := show.Bounds(d)
+
+			if !t.Before(start) && t.Before(end) {
+				return &scheduleSlot{&show.scheduleProgramme, start}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findHoliday returns holiday for given day
+func (s *programmeSchedule) findHoliday(day time.Time) *scheduleHoliday {
+	for _, h := range s.Holidays {
+		if h.date.Equal(day) {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// HasWeekday returns true if show is on air on given weekday
+func (s *scheduleShow) HasWeekday(weekday time.Weekday) bool {
+	for _, w := range s.weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bounds returns start and end time of show on given day. Wall clock time is
+// used, so shows are not shifted on DST transition days.
+func (s *scheduleShow) Bounds(day time.Time) (time.Time, time.Time) {
+	y, m, d := day.Date()
+
+	start := time.Date(
+		y, m, d, int(s.start/time.Hour), int(s.start%time.Hour/time.Minute),
+		0, 0, day.Location(),
+	)
+
+	// Show ends after midnight
+	if s.end <= s.start {
+		d++
+	}
+
+	end := time.Date(
+		y, m, d, int(s.end/time.Hour), int(s.end%time.Hour/time.Minute),
+		0, 0, day.Location(),
+	)
+
+	return start, end
+}
+
+// ToMeta converts programme info to Icecast track metadata
+func (p *scheduleProgramme) ToMeta() ic.TrackMeta {
+	meta := ic.TrackMeta{Artist: p.Host, Title: p.Name}
+
+	if p.Description != "" {
+		meta.Title += ": " + p.Description
+	}
+
+	return meta
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readSchedule reads and validates schedule from file
+func readSchedule(file string) (*programmeSchedule, error) {
+	schedule := &programmeSchedule{}
+	err := jsonutil.Read(file, schedule)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read schedule %s: %w", file, err)
+	}
+
+	err = schedule.validate()
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid schedule %s: %w", file, err)
+	}
+
+	return schedule, nil
+}
+
+// validate validates schedule and parses dates and times
+func (s *programmeSchedule) validate() error {
+	var err error
+
+	s.location = time.Local
+
+	if s.Timezone != "" {
+		s.location, err = time.LoadLocation(s.Timezone)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, show := range s.Shows {
+		if show.Name == "" {
+			return fmt.Errorf("show %d has no name", i+1)
+		}
+
+		if len(show.Days) == 0 {
+			return fmt.Errorf("show %q has no days", show.Name)
+		}
+
+		for _, d := range show.Days {
+			weekday, ok := scheduleWeekdays[strings.ToLower(d)[:min(len(d), 3)]]
+
+			if !ok {
+				return fmt.Errorf("show %q has invalid day %q", show.Name, d)
+			}
+
+			show.weekdays = append(show.weekdays, weekday)
+		}
+
+		show.start, err = parseScheduleTime(show.Start)
+
+		if err != nil {
+			return fmt.Errorf("show %q has invalid start time: %w", show.Name, err)
+		}
+
+		show.end, err = parseScheduleTime(show.End)
+
+		if err != nil {
+			return fmt.Errorf("show %q has invalid end time: %w", show.Name, err)
+		}
+	}
+
+	for i, o := range s.Overrides {
+		if o.Name == "" {
+			return fmt.Errorf("override %d has no name", i+1)
+		}
+
+		o.start, err = time.ParseInLocation("2006-01-02 15:04", o.Start, s.location)
+
+		if err != nil {
+			return fmt.Errorf("override %q has invalid start date: %w", o.Name, err)
+		}
+
+		o.end, err = time.ParseInLocation("2006-01-02 15:04", o.End, s.location)
+
+		if err != nil {
+			return fmt.Errorf("override %q has invalid end date: %w", o.Name, err)
+		}
+
+		if !o.end.After(o.start) {
+			return fmt.Errorf("override %q ends before start", o.Name)
+		}
+	}
+
+	for _, h := range s.Holidays {
+		h.date, err = time.ParseInLocation("2006-01-02", h.Date, s.location)
+
+		if err != nil {
+			return fmt.Errorf("holiday has invalid date: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// parseScheduleTime parses time of day in "HH:MM" format
+func parseScheduleTime(data string) (time.Duration, error) {
+	t, err := time.Parse("15:04", data)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdSchedule shows help for "schedule" command
+func helpCmdSchedule() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Reads programme schedule and updates metadata for one or more mountpoints")
+	fmtc.Println("  when show starts. Host name is used as artist, show name and description are")
+	fmtc.Println("  used as title. Metadata is not changed between shows, so track metadata from")
+	fmtc.Println("  playout is used. Schedule is reloaded automatically on change.")
+	fmtc.NewLine()
+	fmtc.Println("  Schedule is JSON file with weekly grid, one-off overrides and holidays.")
+	fmtc.Println("  Overrides have the highest priority. Weekly grid is ignored on holidays,")
+	fmtc.Println("  holiday with name is on air for the whole day:")
+	fmtc.NewLine()
+	fmtc.Println("    {s}{{!}")
+	fmtc.Println("      {s}\"timezone\": \"Europe/London\",{!}")
+	fmtc.Println("      {s}\"shows\": [{!}")
+	fmtc.Println("        {s}{\"days\": [\"mon\", \"fri\"], \"start\": \"08:00\", \"end\": \"10:00\",{!}")
+	fmtc.Println("         {s}\"name\": \"Morning Show\", \"host\": \"Ann\", \"description\": \"News and talks\"}{!}")
+	fmtc.Println("      {s}],{!}")
+	fmtc.Println("      {s}\"overrides\": [{!}")
+	fmtc.Println("        {s}{\"start\": \"2025-06-01 18:00\", \"end\": \"2025-06-01 21:00\", \"name\": \"Live Concert\"}{!}")
+	fmtc.Println("      {s}],{!}")
+	fmtc.Println("      {s}\"holidays\": [{!}")
+	fmtc.Println("        {s}{\"date\": \"2025-12-25\", \"name\": \"Christmas Special\"}{!}")
+	fmtc.Println("      {s}]{!}")
+	fmtc.Println("    {s}}{!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}file mount…{!}", APP, CMD_SCHEDULE)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}file {!} - Path to schedule file")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-14s{!} - Schedule check interval {s-}(default: 5s){!}", options.F(OPT_INTERVAL))
+	fmtc.Printfn("  {g}%-14s{!} - Don't update anything, only log actions", options.F(OPT_DRY_RUN))
+	fmtc.Printfn("  {g}%-14s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s schedule.json /source1.ogg /source1.mp3", APP, CMD_SCHEDULE)
+	fmtc.Printfn("  %s %s %s schedule.json /source1.ogg", APP, CMD_SCHEDULE, options.F(OPT_DRY_RUN))
+	fmtc.NewLine()
+}
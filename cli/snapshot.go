@@ -0,0 +1,368 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/jsonutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	SNAPSHOT_SAVE = "save"
+	SNAPSHOT_DIFF = "diff"
+)
+
+const (
+	CHANGE_JOINED = "joined"
+	CHANGE_LEFT   = "left"
+	CHANGE_MOVED  = "moved"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// clientsSnapshot contains state of clients at some moment
+type clientsSnapshot struct {
+	Date    time.Time         `json:"date"`
+	Host    string            `json:"host"`
+	Mount   string            `json:"mount,omitempty"`
+	Clients []*snapshotClient `json:"clients"`
+}
+
+// snapshotClient contains info about client
+type snapshotClient struct {
+	Mount     string `json:"mount"`
+	ID        int    `json:"id"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+}
+
+// snapshotChange contains info about client state change
+type snapshotChange struct {
+	Type   string
+	Client *snapshotClient
+	From   string
+	To     string
+}
+
+// snapshotDiff contains difference between two snapshots
+type snapshotDiff struct {
+	Stayed      int
+	Reconnected int
+	Changes     []*snapshotChange
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// processSnapshotCommand processes snapshot subcommands
+func processSnapshotCommand(args options.Arguments) {
+	checkForRequiredArgs(args, 2)
+
+	switch args.Get(1).ToLower().String() {
+	case SNAPSHOT_SAVE:
+		saveSnapshot(args.Get(2).String(), args.Get(3).String())
+	case SNAPSHOT_DIFF:
+		diffSnapshots(args.Get(2).String(), args.Get(3).String())
+	default:
+		printErrorExit("Unknown snapshot action %q", args.Get(1).String())
+	}
+}
+
+// saveSnapshot saves state of clients of given or all mount points to file
+func saveSnapshot(file, mount string) {
+	if mount != "" {
+		mount = formatMount(mount)
+	}
+
+	snapshot, err := takeSnapshot(mount)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	err = jsonutil.Write(file, snapshot, 0644)
+
+	if err != nil {
+		printErrorExit("Can't save snapshot: %v", err)
+	}
+
+	fmtc.Printfn(
+		"{g}Snapshot with %s clients successfully saved to %s{!}",
+		fmtutil.PrettyNum(len(snapshot.Clients)), file,
+	)
+}
+
+// diffSnapshots shows difference between two snapshots or between snapshot
+// and current state
+func diffSnapshots(oldFile, newFile string) {
+	oldSnapshot, err := readSnapshot(oldFile)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	var newSnapshot *clientsSnapshot
+
+	// Current state always contains all mount points, so listeners moved from
+	// the mount point of old snapshot are not treated as left
+	if newFile != "" {
+		newSnapshot, err = readSnapshot(newFile)
+	} else {
+		newSnapshot, err = takeSnapshot("")
+	}
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	printSnapshotDiff(oldSnapshot, newSnapshot, compareSnapshots(oldSnapshot, newSnapshot))
+}
+
+// takeSnapshot creates snapshot with current state of clients
+func takeSnapshot(mount string) (*clientsSnapshot, error) {
+	clients, err := listMountsClients(mount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &clientsSnapshot{
+		Date:  time.Now(),
+		Host:  getHostURL(),
+		Mount: mount,
+	}
+
+	for _, c := range clients {
+		snapshot.Clients = append(snapshot.Clients, &snapshotClient{
+			Mount:     c.Mount,
+			ID:        c.ID,
+			IP:        c.IP,
+			UserAgent: c.UserAgent,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// readSnapshot reads snapshot from file
+func readSnapshot(file string) (*clientsSnapshot, error) {
+	snapshot := &clientsSnapshot{}
+	err := jsonutil.Read(file, snapshot)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read snapshot %s: %w", file, err)
+	}
+
+	return snapshot, nil
+}
+
+// compareSnapshots compares two snapshots. Listeners are identified by IP and
+// user agent, because client ID changes after reconnect. If old snapshot contains
+// only one mount point, listeners of other mount points in new snapshot are used
+// only for detecting moved listeners.
+func compareSnapshots(oldSnapshot, newSnapshot *clientsSnapshot) *snapshotDiff {
+	diff := &snapshotDiff{}
+	oldIndex := indexSnapshotClients(oldSnapshot)
+	newIndex := indexSnapshotClients(newSnapshot)
+
+	for key, oldClients := range oldIndex {
+		newClients := newIndex[key]
+
+		// First match clients which stay on the same mount point
+		for i := 0; i < len(oldClients); i++ {
+			for j := 0; j < len(newClients); j++ {
+				if oldClients[i].Mount != newClients[j].Mount {
+					continue
+				}
+
+				if oldClients[i].ID == newClients[j].ID {
+					diff.Stayed++
+				} else {
+					diff.Reconnected++
+				}
+
+				oldClients = append(oldClients[:i], oldClients[i+1:]...)
+				newClients = append(newClients[:j], newClients[j+1:]...)
+				i--
+
+				break
+			}
+		}
+
+		for len(oldClients) != 0 && len(newClients) != 0 {
+			diff.Changes = append(diff.Changes, &snapshotChange{
+				Type:   CHANGE_MOVED,
+				Client: newClients[0],
+				From:   oldClients[0].Mount,
+				To:     newClients[0].Mount,
+			})
+
+			oldClients, newClients = oldClients[1:], newClients[1:]
+		}
+
+		for _, c := range oldClients {
+			diff.Changes = append(diff.Changes, &snapshotChange{
+				Type: CHANGE_LEFT, Client: c, From: c.Mount,
+			})
+		}
+
+		newIndex[key] = newClients
+	}
+
+	for _, newClients := range newIndex {
+		for _, c := range newClients {
+			if !isSnapshotClientMatch(c, oldSnapshot.Mount) {
+				continue
+			}
+
+			diff.Changes = append(diff.Changes, &snapshotChange{
+				Type: CHANGE_JOINED, Client: c, To: c.Mount,
+			})
+		}
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].Type != diff.Changes[j].Type {
+			return diff.Changes[i].Type < diff.Changes[j].Type
+		}
+
+		return diff.Changes[i].Client.IP < diff.Changes[j].Client.IP
+	})
+
+	return diff
+}
+
+// indexSnapshotClients groups snapshot clients by IP and user agent
+func indexSnapshotClients(snapshot *clientsSnapshot) map[string][]*snapshotClient {
+	index := map[string][]*snapshotClient{}
+
+	for _, c := range snapshot.Clients {
+		key := c.IP + "|" + c.UserAgent
+		index[key] = append(index[key], c)
+	}
+
+	return index
+}
+
+// isSnapshotClientMatch returns true if client matches snapshot mount filter
+func isSnapshotClientMatch(c *snapshotClient, mount string) bool {
+	return mount == "" || c.Mount == mount
+}
+
+// countSnapshotClients returns number of snapshot clients matching mount filter
+func countSnapshotClients(snapshot *clientsSnapshot, mount string) int {
+	var count int
+
+	for _, c := range snapshot.Clients {
+		if isSnapshotClientMatch(c, mount) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// printSnapshotDiff prints difference between snapshots
+func printSnapshotDiff(oldSnapshot, newSnapshot *clientsSnapshot, diff *snapshotDiff) {
+	var joined, left, moved int
+
+	for _, c := range diff.Changes {
+		switch c.Type {
+		case CHANGE_JOINED:
+			joined++
+		case CHANGE_LEFT:
+			left++
+		case CHANGE_MOVED:
+			moved++
+		}
+	}
+
+	fmtc.NewLine()
+	showSeparator(false)
+	fmtc.Printfn(
+		" {*}Snapshot diff{!} {s-}(%s → %s){!}",
+		timeutil.Format(oldSnapshot.Date, "%Y/%m/%d %H:%M:%S"),
+		timeutil.Format(newSnapshot.Date, "%Y/%m/%d %H:%M:%S"),
+	)
+	showSeparator(false)
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} %s", "Clients Before", fmtutil.PrettyNum(len(oldSnapshot.Clients)))
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} %s", "Clients After", fmtutil.PrettyNum(countSnapshotClients(newSnapshot, oldSnapshot.Mount)))
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} %s", "Stayed", fmtutil.PrettyNum(diff.Stayed))
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} %s", "Reconnected", fmtutil.PrettyNum(diff.Reconnected))
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} {c}%s{!}", "Moved", fmtutil.PrettyNum(moved))
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} {g}%s{!}", "Joined", fmtutil.PrettyNum(joined))
+	fmtc.Printfn(" {*}%-28s{!} {s}|{!} {r}%s{!}", "Left", fmtutil.PrettyNum(left))
+	showSeparator(false)
+
+	if len(diff.Changes) == 0 {
+		fmtc.NewLine()
+		return
+	}
+
+	t := table.NewTable("change", "ip", "from", "to", "user-agent")
+	t.SetAlignments(table.ALIGN_LEFT, table.ALIGN_RIGHT, table.ALIGN_LEFT, table.ALIGN_LEFT)
+	t.SetSizes(6, 14, 20, 20)
+
+	fmtc.NewLine()
+
+	for _, c := range diff.Changes {
+		var change string
+
+		switch c.Type {
+		case CHANGE_JOINED:
+			change = "{g}" + c.Type + "{!}"
+		case CHANGE_LEFT:
+			change = "{r}" + c.Type + "{!}"
+		default:
+			change = "{c}" + c.Type + "{!}"
+		}
+
+		t.Print(change, c.Client.IP, formatString(c.From), formatString(c.To), c.Client.UserAgent)
+	}
+
+	t.Separator()
+
+	fmtc.NewLine()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdSnapshot shows help for "snapshot" command
+func helpCmdSnapshot() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Saves state of clients of one or all mountpoints to the file and shows which")
+	fmtc.Println("  listeners joined, left or changed mountpoint between two snapshots or between")
+	fmtc.Println("  snapshot and current state. Listeners are matched by IP and user agent, so")
+	fmtc.Println("  reconnected listeners are not treated as new ones.")
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s %s{!} {g}file mount{!}", APP, CMD_SNAPSHOT, SNAPSHOT_SAVE)
+	fmtc.Printfn("  {c*}%s{!} {y}%s %s{!} {g}file new-file{!}", APP, CMD_SNAPSHOT, SNAPSHOT_DIFF)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}file    {!} - Path to snapshot file")
+	fmtc.Println("  {g}mount   {!} - Mount name {s-}(optional, with or without leading slash){!}")
+	fmtc.Println("  {g}new-file{!} - Path to newer snapshot file {s-}(optional, current state is used if not set){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s %s before-deploy.json", APP, CMD_SNAPSHOT, SNAPSHOT_SAVE)
+	fmtc.Printfn("  %s %s %s before-move.json /source1.ogg", APP, CMD_SNAPSHOT, SNAPSHOT_SAVE)
+	fmtc.Printfn("  %s %s %s before-deploy.json", APP, CMD_SNAPSHOT, SNAPSHOT_DIFF)
+	fmtc.Printfn("  %s %s %s before-deploy.json after-deploy.json", APP, CMD_SNAPSHOT, SNAPSHOT_DIFF)
+	fmtc.NewLine()
+}
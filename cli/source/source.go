@@ -0,0 +1,308 @@
+package source
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	METHOD_PUT    = "PUT"
+	METHOD_SOURCE = "SOURCE"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains source connection configuration
+type Config struct {
+	URL         string // Server URL (e.g. http://127.0.0.1:8000)
+	Mount       string
+	User        string
+	Password    string
+	ContentType string
+	Timeout     time.Duration
+	Info        *Info
+}
+
+// Info contains stream info sent with ice-* headers
+type Info struct {
+	Name        string
+	Description string
+	Genre       string
+	URL         string
+	Public      bool
+	AudioInfo   map[string]string // ice-audio-info (e.g. bitrate, samplerate, channels)
+}
+
+// Conn is source client connection
+type Conn struct {
+	Method string // Method used for connection (PUT or SOURCE)
+
+	conn net.Conn
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UserAgent is user agent used for source connections
+var UserAgent = "icecli"
+
+// ErrUnsupportedMethod is returned if server doesn't support PUT method
+var ErrUnsupportedMethod = errors.New("Method is not supported by server")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Connect connects to the server as a source client. HTTP PUT is used by default,
+// legacy SOURCE method is used if server doesn't support PUT.
+func Connect(cfg *Config) (*Conn, error) {
+	switch {
+	case cfg == nil:
+		return nil, errors.New("Configuration is nil")
+	case cfg.Mount == "":
+		return nil, errors.New("Mount is empty")
+	case cfg.ContentType == "":
+		return nil, errors.New("Content type is empty")
+	}
+
+	conn, err := connect(cfg, METHOD_PUT)
+
+	if err == nil {
+		return conn, nil
+	}
+
+	if !errors.Is(err, ErrUnsupportedMethod) {
+		return nil, err
+	}
+
+	return connect(cfg, METHOD_SOURCE)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes audio data to the stream
+func (c *Conn) Write(data []byte) (int, error) {
+	if c == nil || c.conn == nil {
+		return 0, errors.New("Connection is nil")
+	}
+
+	return c.conn.Write(data)
+}
+
+// Close closes source connection
+func (c *Conn) Close() error {
+	if c == nil || c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// connect connects to the server using given method
+func connect(cfg *Config, method string) (*Conn, error) {
+	u, err := url.Parse(cfg.URL)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse server URL: %w", err)
+	}
+
+	conn, err := dial(u, cfg.Timeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(cfg.Timeout))
+	}
+
+	_, err = conn.Write([]byte(genRequest(cfg, u, method)))
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("Can't send request: %w", err)
+	}
+
+	err = readResponse(bufio.NewReader(conn), method)
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	return &Conn{Method: method, conn: conn}, nil
+}
+
+// dial opens TCP (or TLS) connection to the server
+func dial(u *url.URL, timeout time.Duration) (net.Conn, error) {
+	host := u.Host
+
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "https":
+			host = net.JoinHostPort(u.Hostname(), "443")
+		default:
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+
+	if u.Scheme == "https" {
+		return tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	}
+
+	return dialer.Dial("tcp", host)
+}
+
+// genRequest generates request headers
+func genRequest(cfg *Config, u *url.URL, method string) string {
+	var buf strings.Builder
+
+	mount := "/" + strings.TrimLeft(cfg.Mount, "/")
+	auth := base64.StdEncoding.EncodeToString([]byte(cfg.User + ":" + cfg.Password))
+
+	if method == METHOD_PUT {
+		fmt.Fprintf(&buf, "PUT %s HTTP/1.1\r\n", mount)
+		fmt.Fprintf(&buf, "Host: %s\r\n", u.Host)
+		buf.WriteString("Expect: 100-continue\r\n")
+		buf.WriteString("Transfer-Encoding: identity\r\n")
+	} else {
+		fmt.Fprintf(&buf, "SOURCE %s HTTP/1.0\r\n", mount)
+	}
+
+	fmt.Fprintf(&buf, "Authorization: Basic %s\r\n", auth)
+	fmt.Fprintf(&buf, "User-Agent: %s\r\n", UserAgent)
+	fmt.Fprintf(&buf, "Content-Type: %s\r\n", cfg.ContentType)
+
+	if cfg.Info != nil {
+		writeInfoHeaders(&buf, cfg.Info)
+	}
+
+	buf.WriteString("\r\n")
+
+	return buf.String()
+}
+
+// writeInfoHeaders writes ice-* headers with stream info
+func writeInfoHeaders(buf *strings.Builder, info *Info) {
+	headers := []struct{ name, value string }{
+		{"ice-name", info.Name},
+		{"ice-description", info.Description},
+		{"ice-genre", info.Genre},
+		{"ice-url", info.URL},
+	}
+
+	for _, h := range headers {
+		if h.value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", h.name, sanitizeHeader(h.value))
+		}
+	}
+
+	if info.Public {
+		buf.WriteString("ice-public: 1\r\n")
+	} else {
+		buf.WriteString("ice-public: 0\r\n")
+	}
+
+	if len(info.AudioInfo) != 0 {
+		var audioInfo []string
+
+		for _, k := range []string{"bitrate", "samplerate", "channels", "quality"} {
+			if info.AudioInfo[k] != "" {
+				audioInfo = append(audioInfo, k+"="+url.QueryEscape(info.AudioInfo[k]))
+			}
+		}
+
+		if len(audioInfo) != 0 {
+			fmt.Fprintf(buf, "ice-audio-info: %s\r\n", strings.Join(audioInfo, ";"))
+		}
+	}
+}
+
+// readResponse reads and checks server response
+func readResponse(r *bufio.Reader, method string) error {
+	status, err := r.ReadString('\n')
+
+	if err != nil {
+		if method == METHOD_PUT {
+			return ErrUnsupportedMethod
+		}
+
+		return fmt.Errorf("Can't read response: %w", err)
+	}
+
+	// Read and skip response headers
+	for {
+		line, err := r.ReadString('\n')
+
+		if err != nil || strings.TrimSpace(line) == "" {
+			break
+		}
+	}
+
+	proto, code, text := parseStatusLine(status)
+
+	switch {
+	case proto == "" && method == METHOD_PUT:
+		return ErrUnsupportedMethod
+	case proto == "":
+		return fmt.Errorf("Can't parse response %q", strings.TrimSpace(status))
+	}
+
+	switch code {
+	case 100, 200:
+		return nil
+	case 400, 405, 501:
+		if method == METHOD_PUT {
+			return ErrUnsupportedMethod
+		}
+	case 401:
+		return errors.New("Authentication failed")
+	case 403:
+		return fmt.Errorf("Server rejected source (%s)", text)
+	}
+
+	return fmt.Errorf("Server returned status code %d (%s)", code, text)
+}
+
+// parseStatusLine parses response status line
+func parseStatusLine(status string) (string, int, string) {
+	proto, rest, _ := strings.Cut(strings.TrimSpace(status), " ")
+	codeStr, text, _ := strings.Cut(rest, " ")
+
+	if !strings.HasPrefix(proto, "HTTP/") && proto != "ICY" {
+		return "", 0, ""
+	}
+
+	code, err := strconv.Atoi(codeStr)
+
+	if err != nil {
+		return "", 0, ""
+	}
+
+	return proto, code, text
+}
+
+// sanitizeHeader removes line breaks from header value
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
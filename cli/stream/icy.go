@@ -0,0 +1,83 @@
+package stream
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readICY reads stream with interleaved ICY metadata blocks
+func readICY(r io.Reader, metaInt int, audio io.Writer, handler func(meta *Meta)) error {
+	buf := make([]byte, 255*16)
+
+	for {
+		_, err := io.CopyN(audio, r, int64(metaInt))
+
+		if err != nil {
+			return err
+		}
+
+		_, err = io.ReadFull(r, buf[:1])
+
+		if err != nil {
+			return err
+		}
+
+		size := int(buf[0]) * 16
+
+		if size == 0 {
+			continue
+		}
+
+		_, err = io.ReadFull(r, buf[:size])
+
+		if err != nil {
+			return err
+		}
+
+		handler(ParseICYMeta(string(bytes.TrimRight(buf[:size], "\x00"))))
+	}
+}
+
+// ParseICYMeta parses ICY metadata block (StreamTitle='…';StreamUrl='…';)
+func ParseICYMeta(data string) *Meta {
+	meta := &Meta{Comments: map[string]string{}}
+
+	for data != "" {
+		key, rest, ok := strings.Cut(data, "='")
+
+		if !ok {
+			break
+		}
+
+		// Value may contain quotes, so only "';" is treated as the end of value
+		value, tail, ok := strings.Cut(rest, "';")
+
+		if !ok {
+			value, tail = strings.TrimSuffix(rest, "'"), ""
+		}
+
+		key = strings.TrimSpace(key)
+		meta.Comments[key] = value
+
+		switch key {
+		case "StreamTitle":
+			meta.StreamTitle = value
+		case "StreamUrl":
+			meta.StreamURL = value
+		}
+
+		data = tail
+	}
+
+	return meta
+}
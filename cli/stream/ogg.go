@@ -0,0 +1,274 @@
+package stream
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	OGG_FLAG_CONTINUED = 0x01
+	OGG_FLAG_BOS       = 0x02
+	OGG_FLAG_EOS       = 0x04
+)
+
+// MAX_COMMENT_SIZE is max size of comment packet (with embedded artwork)
+const MAX_COMMENT_SIZE = 16 * 1024 * 1024
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OggPage is Ogg page
+type OggPage struct {
+	Flags   byte
+	Granule uint64
+	Serial  uint32
+	Lacing  []byte
+	Data    []byte
+	Raw     []byte // Full page with header
+}
+
+// oggPacketState is state of packet assembling for logical stream
+type oggPacketState struct {
+	buf     []byte
+	collect bool
+	started bool
+	skip    bool
+	flac    bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	oggCapture     = []byte("OggS")
+	vorbisComments = []byte("\x03vorbis")
+	opusComments   = []byte("OpusTags")
+	flacHeader     = []byte("\x7fFLAC")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadOggPage reads next Ogg page. Data before capture pattern is skipped.
+func ReadOggPage(r *bufio.Reader) (*OggPage, error) {
+	err := syncOggPage(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := r.Peek(27)
+
+	if err != nil {
+		return nil, err
+	}
+
+	segments := int(header[26])
+	lacing, err := r.Peek(27 + segments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	size := 27 + segments
+
+	for _, l := range lacing[27:] {
+		size += int(l)
+	}
+
+	raw := make([]byte, size)
+	_, err = io.ReadFull(r, raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &OggPage{
+		Flags:   raw[5],
+		Granule: binary.LittleEndian.Uint64(raw[6:14]),
+		Serial:  binary.LittleEndian.Uint32(raw[14:18]),
+		Lacing:  raw[27 : 27+segments],
+		Data:    raw[27+segments:],
+		Raw:     raw,
+	}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readOgg reads Ogg stream and calls handler for every comment header
+func readOgg(r io.Reader, handler func(meta *Meta)) error {
+	br := bufio.NewReader(r)
+	streams := map[uint32]*oggPacketState{}
+
+	for {
+		page, err := ReadOggPage(br)
+
+		if err != nil {
+			return err
+		}
+
+		if page.Flags&OGG_FLAG_BOS != 0 {
+			streams[page.Serial] = &oggPacketState{}
+		}
+
+		state := streams[page.Serial]
+
+		if state == nil {
+			// Skip tail of packet which was started before we joined the stream
+			state = &oggPacketState{skip: page.Flags&OGG_FLAG_CONTINUED != 0}
+			streams[page.Serial] = state
+		}
+
+		offset := 0
+
+		for _, l := range page.Lacing {
+			segment := page.Data[offset : offset+int(l)]
+			offset += int(l)
+
+			if state.skip {
+				state.skip = l == 255
+				continue
+			}
+
+			if !state.started {
+				state.started = true
+				state.flac = state.flac || bytes.HasPrefix(segment, flacHeader)
+				state.collect = bytes.HasPrefix(segment, vorbisComments) ||
+					bytes.HasPrefix(segment, opusComments) ||
+					(state.flac && isFLACCommentBlock(segment))
+			}
+
+			if state.collect && len(state.buf)+len(segment) <= MAX_COMMENT_SIZE {
+				state.buf = append(state.buf, segment...)
+			}
+
+			if l == 255 {
+				continue
+			}
+
+			// Packet is complete
+			if state.collect {
+				meta, err := ParseComments(state.buf)
+
+				if err == nil {
+					handler(meta)
+				}
+			}
+
+			state.buf, state.collect, state.started = nil, false, false
+		}
+
+		if page.Flags&OGG_FLAG_EOS != 0 {
+			delete(streams, page.Serial)
+		}
+	}
+}
+
+// ParseComments parses Vorbis comment header (Vorbis, Opus or FLAC)
+func ParseComments(data []byte) (*Meta, error) {
+	switch {
+	case bytes.HasPrefix(data, vorbisComments):
+		data = data[len(vorbisComments):]
+	case bytes.HasPrefix(data, opusComments):
+		data = data[len(opusComments):]
+	case isFLACCommentBlock(data) && int(data[1])<<16|int(data[2])<<8|int(data[3]) == len(data)-4:
+		data = data[4:]
+	default:
+		return nil, fmt.Errorf("Unknown comment header")
+	}
+
+	meta := &Meta{Comments: map[string]string{}}
+
+	vendor, data, err := readCommentString(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Comments["VENDOR"] = vendor
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("Comment header is truncated")
+	}
+
+	count := binary.LittleEndian.Uint32(data)
+	data = data[4:]
+
+	for i := uint32(0); i < count; i++ {
+		var comment string
+
+		comment, data, err = readCommentString(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		key, value, ok := strings.Cut(comment, "=")
+
+		if !ok {
+			continue
+		}
+
+		key = strings.ToUpper(key)
+
+		if _, exist := meta.Comments[key]; !exist {
+			meta.Comments[key] = value
+		}
+	}
+
+	meta.Artist = meta.Comments["ARTIST"]
+	meta.Title = meta.Comments["TITLE"]
+
+	return meta, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isFLACCommentBlock returns true if data starts with header of FLAC
+// VORBIS_COMMENT metadata block
+func isFLACCommentBlock(data []byte) bool {
+	return len(data) >= 4 && data[0]&0x7F == 4
+}
+
+// syncOggPage skips data until Ogg capture pattern
+func syncOggPage(r *bufio.Reader) error {
+	for {
+		data, err := r.Peek(4)
+
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(data, oggCapture) {
+			return nil
+		}
+
+		r.Discard(1)
+	}
+}
+
+// readCommentString reads length-prefixed string from comment header
+func readCommentString(data []byte) (string, []byte, error) {
+	if len(data) < 4 {
+		return "", nil, fmt.Errorf("Comment header is truncated")
+	}
+
+	size := binary.LittleEndian.Uint32(data)
+	data = data[4:]
+
+	if uint64(size) > uint64(len(data)) {
+		return "", nil, fmt.Errorf("Comment header is truncated")
+	}
+
+	return string(data[:size]), data[size:], nil
+}
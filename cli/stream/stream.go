@@ -0,0 +1,232 @@
+package stream
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_ICY = "icy"
+	FORMAT_OGG = "ogg"
+	FORMAT_RAW = "raw"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Meta is in-band track metadata
+type Meta struct {
+	StreamTitle string // ICY StreamTitle
+	StreamURL   string // ICY StreamUrl
+	Artist      string // Ogg ARTIST comment
+	Title       string // Ogg TITLE comment
+	Comments    map[string]string
+}
+
+// Conn is listener connection to the stream
+type Conn struct {
+	ContentType string
+	Format      string
+	MetaInt     int
+	Header      http.Header
+
+	ConnectTime   time.Duration // Time to establish connection
+	FirstByteTime time.Duration // Time to the first byte of response
+
+	body *idleReader
+}
+
+// idleReader closes underlying reader if single read takes longer than timeout
+type idleReader struct {
+	r       io.ReadCloser
+	timer   *time.Timer
+	timeout time.Duration
+	expired atomic.Bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UserAgent is user agent used for listener connections
+var UserAgent = "icecli"
+
+// ErrIdleTimeout is returned if server doesn't send any data for too long
+var ErrIdleTimeout = errors.New("No data received from server")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Open connects to the stream as a listener and requests ICY metadata. Timeout
+// is used for connection and for every read from the stream, so stalled or
+// half-open connection is closed with ErrIdleTimeout.
+func Open(url string, timeout time.Duration) (*Conn, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Icy-MetaData", "1")
+	req.Header.Set("User-Agent", UserAgent)
+
+	var connectTime, firstByteTime time.Duration
+
+	start := time.Now()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn:              func(httptrace.GotConnInfo) { connectTime = time.Since(start) },
+		GotFirstResponseByte: func() { firstByteTime = time.Since(start) },
+	}))
+
+	// Request has no overall timeout, because stream is endless
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext:           (&net.Dialer{Timeout: timeout}).DialContext,
+			ResponseHeaderTimeout: timeout,
+		},
+	}
+
+	resp, err := httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Server returned status code %d", resp.StatusCode)
+	}
+
+	c := &Conn{
+		ContentType:   resp.Header.Get("Content-Type"),
+		Header:        resp.Header,
+		Format:        FORMAT_RAW,
+		ConnectTime:   connectTime,
+		FirstByteTime: firstByteTime,
+		body:          newIdleReader(resp.Body, timeout),
+	}
+
+	if resp.Header.Get("Icy-Metaint") != "" {
+		c.MetaInt, err = strconv.Atoi(resp.Header.Get("Icy-Metaint"))
+
+		if err != nil || c.MetaInt <= 0 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("Invalid icy-metaint header value %q", resp.Header.Get("Icy-Metaint"))
+		}
+
+		c.Format = FORMAT_ICY
+	} else if IsOgg(c.ContentType) {
+		c.Format = FORMAT_OGG
+	}
+
+	return c, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads stream, writes audio data to given writer (may be nil) and calls
+// handler (may be nil) on every metadata block
+func (c *Conn) Read(audio io.Writer, handler func(meta *Meta)) error {
+	if audio == nil {
+		audio = io.Discard
+	}
+
+	if handler == nil {
+		handler = func(meta *Meta) {}
+	}
+
+	switch c.Format {
+	case FORMAT_ICY:
+		return readICY(bufio.NewReader(c.body), c.MetaInt, audio, handler)
+	case FORMAT_OGG:
+		return readOgg(io.TeeReader(c.body, audio), handler)
+	}
+
+	_, err := io.Copy(audio, c.body)
+
+	return err
+}
+
+// Close closes connection
+func (c *Conn) Close() error {
+	return c.body.Close()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newIdleReader creates new reader with idle timeout
+func newIdleReader(r io.ReadCloser, timeout time.Duration) *idleReader {
+	ir := &idleReader{r: r, timeout: timeout}
+
+	ir.timer = time.AfterFunc(timeout, func() {
+		ir.expired.Store(true)
+		r.Close()
+	})
+
+	ir.timer.Stop()
+
+	return ir
+}
+
+// Read reads data from underlying reader
+func (r *idleReader) Read(p []byte) (int, error) {
+	r.timer.Reset(r.timeout)
+	n, err := r.r.Read(p)
+	r.timer.Stop()
+
+	if err != nil && r.expired.Load() {
+		return n, fmt.Errorf("%w in %s", ErrIdleTimeout, r.timeout)
+	}
+
+	return n, err
+}
+
+// Close stops timer and closes underlying reader
+func (r *idleReader) Close() error {
+	r.timer.Stop()
+	return r.r.Close()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns track info in "artist - title" format
+func (m *Meta) String() string {
+	switch {
+	case m.StreamTitle != "":
+		return m.StreamTitle
+	case m.Artist == "":
+		return m.Title
+	case m.Title == "":
+		return m.Artist
+	}
+
+	return m.Artist + " - " + m.Title
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsOgg returns true if content type is used for Ogg streams
+func IsOgg(contentType string) bool {
+	contentType, _, _ = strings.Cut(strings.ToLower(contentType), ";")
+
+	switch strings.TrimSpace(contentType) {
+	case "application/ogg", "audio/ogg", "video/ogg", "audio/opus", "audio/vorbis":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,284 @@
+package tags
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ID3_FLAG_UNSYNC   = 0x80
+	ID3_FLAG_EXTENDED = 0x40
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// id3Frames contains frame IDs for every supported field (ID3v2.2 and ID3v2.3+)
+var id3Frames = map[string]string{
+	"TP1": "artist", "TPE1": "artist",
+	"TT2": "title", "TIT2": "title",
+	"TAL": "album", "TALB": "album",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadID3v2 reads ID3v2 tag from the beginning of the stream
+func ReadID3v2(r io.Reader) (*Tags, error) {
+	header := make([]byte, 10)
+	_, err := io.ReadFull(r, header)
+
+	if err != nil || string(header[:3]) != "ID3" {
+		return nil, ErrNoTags
+	}
+
+	version := header[3]
+	flags := header[5]
+
+	if version < 2 || version > 4 {
+		return nil, ErrNoTags
+	}
+
+	data := make([]byte, syncsafeInt(header[6:10]))
+	_, err = io.ReadFull(r, data)
+
+	if err != nil {
+		return nil, ErrNoTags
+	}
+
+	// In ID3v2.4 unsynchronisation is applied for every frame separately
+	if flags&ID3_FLAG_UNSYNC != 0 && version < 4 {
+		data = removeUnsync(data)
+	}
+
+	if flags&ID3_FLAG_EXTENDED != 0 && version > 2 {
+		data = skipExtendedHeader(data, version)
+	}
+
+	tags := &Tags{}
+
+	for len(data) > 0 {
+		var id string
+		var frame []byte
+
+		id, frame, data = readID3Frame(data, version)
+
+		if id == "" {
+			break
+		}
+
+		switch id3Frames[id] {
+		case "artist":
+			tags.Artist = decodeTextFrame(frame)
+		case "title":
+			tags.Title = decodeTextFrame(frame)
+		case "album":
+			tags.Album = decodeTextFrame(frame)
+		}
+	}
+
+	if tags.IsEmpty() {
+		return nil, ErrNoTags
+	}
+
+	return tags, nil
+}
+
+// ReadID3v1 reads ID3v1 tag from the end of the stream
+func ReadID3v1(r io.ReadSeeker) (*Tags, error) {
+	_, err := r.Seek(-128, io.SeekEnd)
+
+	if err != nil {
+		return nil, ErrNoTags
+	}
+
+	data := make([]byte, 128)
+	_, err = io.ReadFull(r, data)
+
+	if err != nil || string(data[:3]) != "TAG" {
+		return nil, ErrNoTags
+	}
+
+	tags := &Tags{
+		Title:  cleanValue(decodeLatin1(data[3:33])),
+		Artist: cleanValue(decodeLatin1(data[33:63])),
+		Album:  cleanValue(decodeLatin1(data[63:93])),
+	}
+
+	if tags.IsEmpty() {
+		return nil, ErrNoTags
+	}
+
+	return tags, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readID3Frame reads next frame from tag data. Returns empty ID if there is
+// no more frames.
+func readID3Frame(data []byte, version byte) (string, []byte, []byte) {
+	var id string
+	var size, headerSize int
+	var flags byte
+
+	if version == 2 {
+		if len(data) < 6 {
+			return "", nil, nil
+		}
+
+		id, headerSize = string(data[:3]), 6
+		size = int(data[3])<<16 | int(data[4])<<8 | int(data[5])
+	} else {
+		if len(data) < 10 {
+			return "", nil, nil
+		}
+
+		id, headerSize, flags = string(data[:4]), 10, data[9]
+
+		if version == 4 {
+			size = syncsafeInt(data[4:8])
+		} else {
+			size = int(binary.BigEndian.Uint32(data[4:8]))
+		}
+	}
+
+	// Padding
+	if id[0] == 0 || size <= 0 || headerSize+size > len(data) {
+		return "", nil, nil
+	}
+
+	frame := data[headerSize : headerSize+size]
+	data = data[headerSize+size:]
+
+	switch version {
+	case 3:
+		switch {
+		case flags&0xC0 != 0: // Compression or encryption
+			return id, nil, data
+		case flags&0x20 != 0 && len(frame) > 0: // Grouping identity
+			frame = frame[1:]
+		}
+
+	case 4:
+		if flags&0x0C != 0 { // Compression or encryption
+			return id, nil, data
+		}
+
+		if flags&0x40 != 0 && len(frame) > 0 { // Grouping identity
+			frame = frame[1:]
+		}
+
+		if flags&0x02 != 0 {
+			frame = removeUnsync(frame)
+		}
+
+		if flags&0x01 != 0 && len(frame) >= 4 { // Data length indicator
+			frame = frame[4:]
+		}
+	}
+
+	return id, frame, data
+}
+
+// decodeTextFrame decodes text information frame
+func decodeTextFrame(frame []byte) string {
+	if len(frame) < 2 {
+		return ""
+	}
+
+	var values []string
+
+	text := frame[1:]
+
+	switch frame[0] {
+	case 0:
+		values = strings.Split(decodeLatin1(text), "\x00")
+	case 1:
+		values = strings.Split(decodeUTF16(text, true), "\x00")
+	case 2:
+		values = strings.Split(decodeUTF16(text, false), "\x00")
+	case 3:
+		values = strings.Split(string(text), "\x00")
+	default:
+		return ""
+	}
+
+	var result []string
+
+	for _, v := range values {
+		v = cleanValue(v)
+
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return strings.Join(result, "/")
+}
+
+// decodeUTF16 decodes UTF-16 string. Byte order is detected using BOM if
+// withBOM is true, otherwise big-endian is used.
+func decodeUTF16(data []byte, withBOM bool) string {
+	var units []uint16
+
+	bigEndian := true
+
+	for len(data) >= 2 {
+		switch {
+		case withBOM && data[0] == 0xFF && data[1] == 0xFE:
+			bigEndian = false
+		case withBOM && data[0] == 0xFE && data[1] == 0xFF:
+			bigEndian = true
+		case bigEndian:
+			units = append(units, binary.BigEndian.Uint16(data))
+		default:
+			units = append(units, binary.LittleEndian.Uint16(data))
+		}
+
+		data = data[2:]
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// removeUnsync removes unsynchronisation scheme (0xFF 0x00 → 0xFF)
+func removeUnsync(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+// skipExtendedHeader skips extended tag header
+func skipExtendedHeader(data []byte, version byte) []byte {
+	if len(data) < 4 {
+		return nil
+	}
+
+	size := int(binary.BigEndian.Uint32(data))
+
+	if version == 4 {
+		size = syncsafeInt(data[:4]) // Size includes size field itself
+	} else {
+		size += 4
+	}
+
+	if size > len(data) {
+		return nil
+	}
+
+	return data[size:]
+}
+
+// syncsafeInt decodes 28-bit syncsafe integer
+func syncsafeInt(data []byte) int {
+	return int(data[0]&0x7F)<<21 | int(data[1]&0x7F)<<14 |
+		int(data[2]&0x7F)<<7 | int(data[3]&0x7F)
+}
@@ -0,0 +1,140 @@
+package tags
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Tags contains basic track tags
+type Tags struct {
+	Artist string
+	Title  string
+	Album  string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// maxOggHeaderPages is max number of pages to read while searching comments
+const maxOggHeaderPages = 16
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrNoTags is returned if file doesn't contain supported tags
+var ErrNoTags = errors.New("File doesn't contain tags")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadFile reads ID3v2/ID3v1 tags from MP3 file or Vorbis comments from Ogg file
+func ReadFile(file string) (*Tags, error) {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(fd, magic)
+
+	if err != nil {
+		return nil, ErrNoTags
+	}
+
+	_, err = fd.Seek(0, io.SeekStart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if string(magic) == "OggS" {
+		return ReadVorbis(fd)
+	}
+
+	tags, err := ReadID3v2(fd)
+
+	if err == nil && !tags.IsEmpty() {
+		return tags, nil
+	}
+
+	return ReadID3v1(fd)
+}
+
+// ReadVorbis reads Vorbis comments from Ogg Vorbis or Opus stream
+func ReadVorbis(r io.Reader) (*Tags, error) {
+	var packet []byte
+
+	br := bufio.NewReader(r)
+
+	for i := 0; i < maxOggHeaderPages; i++ {
+		page, err := stream.ReadOggPage(br)
+
+		if err != nil {
+			return nil, ErrNoTags
+		}
+
+		offset := 0
+
+		for _, l := range page.Lacing {
+			packet = append(packet, page.Data[offset:offset+int(l)]...)
+			offset += int(l)
+
+			if l == 255 {
+				continue
+			}
+
+			meta, err := stream.ParseComments(packet)
+
+			if err == nil {
+				return &Tags{
+					Artist: meta.Artist,
+					Title:  meta.Title,
+					Album:  meta.Comments["ALBUM"],
+				}, nil
+			}
+
+			packet = nil
+		}
+	}
+
+	return nil, ErrNoTags
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsEmpty returns true if tags doesn't contain artist and title
+func (t *Tags) IsEmpty() bool {
+	return t == nil || (t.Artist == "" && t.Title == "")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cleanValue removes padding from tag value
+func cleanValue(value string) string {
+	return strings.TrimSpace(strings.Trim(value, "\x00"))
+}
+
+// decodeLatin1 decodes ISO-8859-1 string
+func decodeLatin1(data []byte) string {
+	runes := make([]rune, len(data))
+
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
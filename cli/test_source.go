@@ -0,0 +1,243 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/log"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/essentialkaos/icecli/cli/audio"
+	"github.com/essentialkaos/icecli/cli/source"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// testSource generates test signal and streams it to mount point as Ogg FLAC
+type testSource struct {
+	Mount    string
+	Signal   *audio.Signal
+	Interval time.Duration
+
+	conn    *source.Conn
+	pacer   *audio.Pacer
+	encoder *audio.FLACEncoder
+	counter int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startTestSource generates test signal and streams it to the mount point
+func startTestSource(mount string) {
+	err := setupLogger()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	interval, err := parseInterval()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	signal, err := audio.NewSignal(
+		options.GetS(OPT_SIGNAL),
+		float64(options.GetI(OPT_FREQUENCY)),
+		audio.FLAC_SAMPLE_RATE,
+	)
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	s := &testSource{
+		Mount:    formatMount(mount),
+		Signal:   signal,
+		Interval: interval,
+		encoder:  &audio.FLACEncoder{},
+	}
+
+	log.Info(
+		"Streaming test signal (%s) to %s, metadata counter interval: %s",
+		s.describeSignal(), s.Mount, interval,
+	)
+
+	for {
+		err = s.Run()
+
+		log.Error("%v, reconnecting in %s…", err, sourceReconnectDelay)
+
+		s.conn.Close()
+		s.conn = nil
+
+		time.Sleep(sourceReconnectDelay)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run connects to the server and streams signal until error
+func (s *testSource) Run() error {
+	conn, err := source.Connect(&source.Config{
+		URL:         getHostURL(),
+		Mount:       s.Mount,
+		User:        options.GetS(OPT_SOURCE_USER),
+		Password:    options.GetS(OPT_SOURCE_PASS),
+		ContentType: audio.ContentType(audio.FORMAT_OGG),
+		Timeout:     streamTimeout,
+		Info:        s.getInfo(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("Can't connect to %s: %w", s.Mount, err)
+	}
+
+	log.Info("Connected to %s using %s method", s.Mount, conn.Method)
+
+	s.conn = conn
+	s.pacer = &audio.Pacer{Lead: pushLead}
+
+	for {
+		err = s.streamSegment()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// streamSegment streams one logical Ogg stream with new counter value in
+// comments. Chaining of logical streams is the way to update metadata of
+// Ogg streams.
+func (s *testSource) streamSegment() error {
+	s.counter++
+	s.Signal.SetCounter(s.counter)
+	s.encoder.Reset()
+
+	ogg := &audio.OggWriter{Serial: rand.Uint32()}
+	title := s.getTitle()
+
+	for _, header := range audio.OggFLACHeaders(APP+" "+VER, []string{"ARTIST=" + APP, "TITLE=" + title}) {
+		err := s.write(ogg.Page(header, 0, false), 0)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("Metadata counter: %s", title)
+
+	frames := int(s.Interval.Seconds() * audio.FLAC_SAMPLE_RATE / audio.FLAC_BLOCK_SIZE)
+	frames = max(frames, 1)
+
+	samples := make([]int16, audio.FLAC_BLOCK_SIZE)
+	duration := time.Duration(audio.FLAC_BLOCK_SIZE) * time.Second / audio.FLAC_SAMPLE_RATE
+
+	for i := 1; i <= frames; i++ {
+		s.Signal.Generate(samples)
+
+		page := ogg.Page(
+			s.encoder.Encode(samples),
+			uint64(i*audio.FLAC_BLOCK_SIZE),
+			i == frames,
+		)
+
+		err := s.write(page, duration)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write sends page to the server with pacing
+func (s *testSource) write(page []byte, duration time.Duration) error {
+	s.pacer.Wait(duration)
+
+	_, err := s.conn.Write(page)
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", errSourceWrite, err)
+	}
+
+	return nil
+}
+
+// getInfo returns stream info for ice-* headers
+func (s *testSource) getInfo() *source.Info {
+	info := getSourceInfo(&audio.Chunk{SampleRate: audio.FLAC_SAMPLE_RATE, Channels: 1})
+
+	if info.Name == "" {
+		info.Name = "Test signal (" + s.describeSignal() + ")"
+	}
+
+	return info
+}
+
+// getTitle returns title with counter
+func (s *testSource) getTitle() string {
+	return fmt.Sprintf("Test signal #%d (%s)", s.counter, s.describeSignal())
+}
+
+// describeSignal returns short signal description
+func (s *testSource) describeSignal() string {
+	switch s.Signal.Kind {
+	case audio.SIGNAL_TONE, audio.SIGNAL_BEEP:
+		return s.Signal.Kind + " " + strconv.Itoa(int(s.Signal.Frequency)) + " Hz"
+	}
+
+	return s.Signal.Kind
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdTestSource shows help for "test-source" command
+func helpCmdTestSource() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Generates test signal and streams it to the mountpoint as Ogg FLAC (44.1 kHz,")
+	fmtc.Println("  16 bit, mono, -18 dBFS). Every interval, the metadata counter is incremented")
+	fmtc.Println("  and sent in-band as a new chained Ogg stream, so it can be used for checking")
+	fmtc.Println("  relays, fallbacks and players.")
+	fmtc.NewLine()
+	fmtc.Println("  Signals:")
+	fmtc.NewLine()
+	fmtc.Printfn("    {y}%-8s{!} {s-}— Sine tone with given frequency{!}", audio.SIGNAL_TONE)
+	fmtc.Printfn("    {y}%-8s{!} {s-}— Logarithmic sweep from 20 Hz to 20 kHz (10 seconds){!}", audio.SIGNAL_SWEEP)
+	fmtc.Printfn("    {y}%-8s{!} {s-}— Digital silence{!}", audio.SIGNAL_SILENCE)
+	fmtc.Printfn("    {y}%-8s{!} {s-}— Beeps coding last digit of metadata counter (0 is a long beep){!}", audio.SIGNAL_BEEP)
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_TEST_SOURCE)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-20s{!} - Signal type {s-}(default: tone){!}", options.F(OPT_SIGNAL))
+	fmtc.Printfn("  {g}%-20s{!} - Tone frequency in Hz {s-}(default: 1000){!}", options.F(OPT_FREQUENCY))
+	fmtc.Printfn("  {g}%-20s{!} - Metadata counter interval {s-}(default: 5s){!}", options.F(OPT_INTERVAL))
+	fmtc.Printfn("  {g}%-20s{!} - Source user {s-}(default: source){!}", options.F(OPT_SOURCE_USER))
+	fmtc.Printfn("  {g}%-20s{!} - Source password {s-}(default: hackme){!}", options.F(OPT_SOURCE_PASS))
+	fmtc.Printfn("  {g}%-20s{!} - Path to log file", options.F(OPT_LOG))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s /test.ogg", APP, CMD_TEST_SOURCE)
+	fmtc.Printfn("  %s %s %s beep %s 30s /test.ogg", APP, CMD_TEST_SOURCE, options.F(OPT_SIGNAL), options.F(OPT_INTERVAL))
+	fmtc.NewLine()
+}
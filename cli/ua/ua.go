@@ -0,0 +1,237 @@
+package ua
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	TYPE_PLAYER = "player"
+	TYPE_APP    = "app"
+	TYPE_OS     = "os"
+	TYPE_DEVICE = "device"
+)
+
+// UNKNOWN is family name used if no rule matched
+const UNKNOWN = "Unknown"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Info contains info about client parsed from user agent
+type Info struct {
+	Player string
+	App    string
+	OS     string
+	Device string
+}
+
+// Rule is classification rule
+type Rule struct {
+	Type    string
+	Family  string
+	Pattern *regexp.Regexp
+}
+
+// Parser is user agent parser
+type Parser struct {
+	rules []*Rule
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+//go:embed rules.txt
+var defaultRules []byte
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewParser creates new parser with embedded rule set
+func NewParser() *Parser {
+	p := &Parser{}
+	err := p.Load(bytes.NewReader(defaultRules))
+
+	if err != nil {
+		panic("Can't load embedded user agent rules: " + err.Error())
+	}
+
+	return p
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LoadFile loads rules from given file. Loaded rules have higher priority
+// than rules loaded before.
+func (p *Parser) LoadFile(file string) error {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	return p.Load(fd)
+}
+
+// Load loads rules from given reader. Loaded rules have higher priority
+// than rules loaded before.
+func (p *Parser) Load(r io.Reader) error {
+	var rules []*Rule
+
+	line := 0
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		rule, err := parseRule(text)
+
+		if err != nil {
+			return fmt.Errorf("Can't parse rule on line %d: %w", line, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if scanner.Err() != nil {
+		return scanner.Err()
+	}
+
+	p.rules = append(rules, p.rules...)
+
+	return nil
+}
+
+// Parse parses user agent
+func (p *Parser) Parse(userAgent string) *Info {
+	info := &Info{}
+
+	for _, r := range p.rules {
+		if !r.Pattern.MatchString(userAgent) {
+			continue
+		}
+
+		switch {
+		case r.Type == TYPE_PLAYER && info.Player == "":
+			info.Player = r.Family
+		case r.Type == TYPE_APP && info.App == "":
+			info.App = r.Family
+		case r.Type == TYPE_OS && info.OS == "":
+			info.OS = r.Family
+		case r.Type == TYPE_DEVICE && info.Device == "":
+			info.Device = r.Family
+		}
+	}
+
+	info.Player = defaultFamily(info.Player)
+	info.App = defaultFamily(info.App)
+	info.OS = defaultFamily(info.OS)
+	info.Device = defaultFamily(info.Device)
+
+	return info
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns family of given type
+func (i *Info) Get(typ string) string {
+	switch typ {
+	case TYPE_PLAYER:
+		return i.Player
+	case TYPE_APP:
+		return i.App
+	case TYPE_OS:
+		return i.OS
+	case TYPE_DEVICE:
+		return i.Device
+	}
+
+	return UNKNOWN
+}
+
+// String returns short string representation of info
+func (i *Info) String() string {
+	var result []string
+
+	for _, f := range []string{i.Player, i.App, i.OS, i.Device} {
+		if f != UNKNOWN && f != "" {
+			result = append(result, f)
+		}
+	}
+
+	if len(result) == 0 {
+		return UNKNOWN
+	}
+
+	return strings.Join(result, "/")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsValidType returns true if given family type is supported
+func IsValidType(typ string) bool {
+	switch typ {
+	case TYPE_PLAYER, TYPE_APP, TYPE_OS, TYPE_DEVICE:
+		return true
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseRule parses rule definition
+func parseRule(data string) (*Rule, error) {
+	fields := strings.SplitN(data, "|", 3)
+
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("rule must contain type, family and pattern")
+	}
+
+	typ := strings.ToLower(strings.TrimSpace(fields[0]))
+	family := strings.TrimSpace(fields[1])
+
+	if !IsValidType(typ) {
+		return nil, fmt.Errorf("unsupported rule type %q", typ)
+	}
+
+	if family == "" {
+		return nil, fmt.Errorf("family name is empty")
+	}
+
+	pattern, err := regexp.Compile(strings.TrimSpace(fields[2]))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rule{Type: typ, Family: family, Pattern: pattern}, nil
+}
+
+// defaultFamily returns family name or UNKNOWN if name is empty
+func defaultFamily(family string) string {
+	if family == "" {
+		return UNKNOWN
+	}
+
+	return family
+}
@@ -0,0 +1,277 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	ic "github.com/essentialkaos/go-icecast/v3"
+
+	"github.com/essentialkaos/icecli/cli/stream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	VERIFY_OK       = "ok"
+	VERIFY_MISMATCH = "mismatch"
+	VERIFY_STALE    = "stale"
+	VERIFY_NO_META  = "no-meta"
+	VERIFY_ERROR    = "error"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// metaCheck contains result of metadata consistency check
+type metaCheck struct {
+	Mount   string
+	Status  string
+	Stats   string
+	Stream  string
+	Updated time.Time
+	Error   error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// verifyMeta compares metadata from stats with metadata embedded into the
+// stream for given or all mount points
+func verifyMeta(mount string) {
+	if mount != "" {
+		mount = formatMount(mount)
+	}
+
+	timeout, err := timeutil.ParseDuration(options.GetS(OPT_TIMEOUT))
+
+	if err != nil {
+		printErrorExit("Can't parse %s value: %v", options.F(OPT_TIMEOUT), err)
+	}
+
+	var maxAge time.Duration
+
+	if options.Has(OPT_MAX_AGE) {
+		maxAge, err = timeutil.ParseDuration(options.GetS(OPT_MAX_AGE))
+
+		if err != nil {
+			printErrorExit("Can't parse %s value: %v", options.F(OPT_MAX_AGE), err)
+		}
+	}
+
+	stats, err := client.GetStats()
+
+	if err != nil {
+		printErrorExit(err.Error())
+	}
+
+	var wg sync.WaitGroup
+	var checks []*metaCheck
+
+	for path, source := range stats.Sources {
+		if mount != "" && path != mount {
+			continue
+		}
+
+		check := &metaCheck{
+			Mount:   path,
+			Stats:   formatSourceTrack(source),
+			Updated: source.MetadataUpdated,
+		}
+
+		checks = append(checks, check)
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			check.Run(timeout, maxAge)
+		}()
+	}
+
+	if len(checks) == 0 {
+		printErrorExit("No sources found for mount %s", formatMountFilter(mount))
+	}
+
+	wg.Wait()
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Mount < checks[j].Mount })
+
+	if printMetaChecks(checks) {
+		os.Exit(1)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run connects to the stream and compares in-band metadata with stats
+func (c *metaCheck) Run(timeout, maxAge time.Duration) {
+	meta, err := readStreamMeta(c.Mount, timeout)
+
+	switch {
+	case err != nil:
+		c.Status, c.Error = VERIFY_ERROR, err
+		return
+	case meta == nil:
+		c.Status = VERIFY_NO_META
+		return
+	}
+
+	c.Stream = meta.String()
+
+	switch {
+	case strings.TrimSpace(c.Stream) != strings.TrimSpace(c.Stats):
+		c.Status = VERIFY_MISMATCH
+	case maxAge > 0 && time.Since(c.Updated) > maxAge:
+		c.Status = VERIFY_STALE
+	default:
+		c.Status = VERIFY_OK
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readStreamMeta connects to the mount point and reads first in-band metadata
+// block. Returns nil if stream doesn't support in-band metadata.
+func readStreamMeta(mount string, timeout time.Duration) (*stream.Meta, error) {
+	conn, err := openStream(mount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if conn.Format == stream.FORMAT_RAW {
+		conn.Close()
+		return nil, nil
+	}
+
+	metaCh := make(chan *stream.Meta, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- conn.Read(nil, func(meta *stream.Meta) {
+			select {
+			case metaCh <- meta:
+			default:
+			}
+		})
+	}()
+
+	defer conn.Close()
+
+	select {
+	case meta := <-metaCh:
+		return meta, nil
+	case err = <-errCh:
+		return nil, fmt.Errorf("Stream reading error: %w", err)
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("No metadata received in %s", timeutil.PrettyDuration(timeout))
+	}
+}
+
+// formatSourceTrack formats track info from source stats
+func formatSourceTrack(source *ic.Source) string {
+	if source.Track == nil {
+		return ""
+	}
+
+	return formatTrackMeta(ic.TrackMeta{
+		Artist: source.Track.Artist,
+		Title:  source.Track.Title,
+	})
+}
+
+// printMetaChecks prints results of metadata checks. Returns true if some
+// checks failed.
+func printMetaChecks(checks []*metaCheck) bool {
+	hasProblems := false
+
+	t := table.NewTable("mount", "status", "stats", "stream", "updated")
+	t.SetSizes(20, 8, 30, 30)
+
+	fmtc.NewLine()
+
+	for _, c := range checks {
+		var status, streamMeta string
+
+		switch c.Status {
+		case VERIFY_OK:
+			status = "{g}" + c.Status + "{!}"
+		case VERIFY_STALE, VERIFY_NO_META:
+			status = "{y}" + c.Status + "{!}"
+			hasProblems = true
+		default:
+			status = "{r}" + c.Status + "{!}"
+			hasProblems = true
+		}
+
+		switch {
+		case c.Error != nil:
+			streamMeta = "{r}" + c.Error.Error() + "{!}"
+		case c.Status == VERIFY_NO_META:
+			streamMeta = "{s-}—{!}"
+		case !utf8.ValidString(c.Stream):
+			streamMeta = formatString(c.Stream) + " {s-}(not UTF-8){!}"
+		default:
+			streamMeta = formatString(c.Stream)
+		}
+
+		t.Print(
+			c.Mount, status, formatString(c.Stats), streamMeta,
+			timeutil.PrettyDuration(time.Since(c.Updated))+" ago",
+		)
+	}
+
+	t.Separator()
+	fmtc.NewLine()
+
+	return hasProblems
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// helpCmdVerifyMeta shows help for "verify-meta" command
+func helpCmdVerifyMeta() {
+	fmtc.NewLine()
+	fmtc.Println("{*}Description:{!}\n")
+	fmtc.Println("  Compares metadata reported by Icecast stats with metadata embedded into the")
+	fmtc.Println("  stream (ICY metadata blocks or Ogg comments) for one or all mountpoints.")
+	fmtc.Println("  Command connects to every mountpoint as a listener and reads the first")
+	fmtc.Println("  metadata block. Command exits with error code if any problem was found.")
+	fmtc.NewLine()
+	fmtc.Println("  Statuses:")
+	fmtc.NewLine()
+	fmtc.Printfn("    {g}%-8s{!} {s-}— Metadata is the same{!}", VERIFY_OK)
+	fmtc.Printfn("    {r}%-8s{!} {s-}— Stream contains different metadata{!}", VERIFY_MISMATCH)
+	fmtc.Printfn("    {y}%-8s{!} {s-}— Metadata wasn't updated longer than max age{!}", VERIFY_STALE)
+	fmtc.Printfn("    {y}%-8s{!} {s-}— Stream doesn't contain metadata{!}", VERIFY_NO_META)
+	fmtc.Printfn("    {r}%-8s{!} {s-}— Can't read stream{!}", VERIFY_ERROR)
+	fmtc.NewLine()
+	fmtc.Println("{*}Usage:{!}\n")
+	fmtc.Printfn("  {c*}%s{!} {y}%s{!} {g}mount{!}", APP, CMD_VERIFY_META)
+	fmtc.NewLine()
+	fmtc.Println("{*}Arguments:{!}\n")
+	fmtc.Println("  {g}mount{!} - Mount name {s-}(optional, with or without leading slash){!}")
+	fmtc.NewLine()
+	fmtc.Println("{*}Options:{!}\n")
+	fmtc.Printfn("  {g}%-13s{!} - Max time for waiting metadata {s-}(default: 10s){!}", options.F(OPT_TIMEOUT))
+	fmtc.Printfn("  {g}%-13s{!} - Max metadata age {s-}(e.g. 15m){!}", options.F(OPT_MAX_AGE))
+	fmtc.NewLine()
+	fmtc.Println("{*}Examples:{!}\n")
+	fmtc.Printfn("  %s %s", APP, CMD_VERIFY_META)
+	fmtc.Printfn("  %s %s %s 30m /source1.mp3", APP, CMD_VERIFY_META, options.F(OPT_MAX_AGE))
+	fmtc.NewLine()
+}
@@ -1,13 +1,21 @@
 module github.com/essentialkaos/icecli
 
-go 1.23.6
+go 1.24.0
 
 require (
 	github.com/essentialkaos/ek/v13 v13.25.0
 	github.com/essentialkaos/go-icecast/v3 v3.0.1
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/hajimehoshi/go-mp3 v0.3.4
+	github.com/jfreymuth/vorbis v1.0.2
+	github.com/oschwald/maxminddb-golang v1.13.1
+	github.com/pion/opus v0.1.0
+	golang.org/x/text v0.25.0
 )
 
 require (
 	github.com/essentialkaos/depsy v1.3.1 // indirect
+	github.com/essentialkaos/go-linenoise/v3 v3.7.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 )
+